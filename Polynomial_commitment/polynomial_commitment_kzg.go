@@ -4,21 +4,26 @@ import (
 	"commitment/primitives"
 	"fmt"
 	"github.com/drand/kyber/group/mod"
-	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
-	"math/big"
 )
 
 // TrustedSetup also named Reference String
 type TrustedSetup struct {
-	Tau1 []*bn256.G1
-	Tau2 []*bn256.G2
+	Curve primitives.Curve
+	Tau1  []primitives.G1
+	Tau2  []primitives.G2
 }
 
-// NewTrustedSetup returns a new trusted setup. This step should be done in a
-// secure & distributed way
+// NewTrustedSetup returns a new trusted setup over BN254. This step should be
+// done in a secure & distributed way
 func NewTrustedSetup(l int) (*TrustedSetup, error) {
+	return NewTrustedSetupOver(primitives.CurveBN254, l)
+}
+
+// NewTrustedSetupOver returns a new trusted setup over the given curve, the
+// polynomials committed with it must be defined over curve.ScalarField()
+func NewTrustedSetupOver(curve primitives.Curve, l int) (*TrustedSetup, error) {
 	// compute random s
-	s, err := primitives.RandModInt()
+	s, err := curve.ScalarField().Rand()
 	if err != nil {
 		return nil, err
 	}
@@ -26,53 +31,43 @@ func NewTrustedSetup(l int) (*TrustedSetup, error) {
 	// τ₁: [x₀]₁, [x₁]₁, [x₂]₁, ..., [x n₋₁]₁
 	// τ₂: [x₀]₂, [x₁]₂, [x₂]₂, ..., [x n₋₁]₂
 
-	// sPow := make([]*big.Int, l)
-	tauG1 := make([]*bn256.G1, l) //g^s, g^s^2,...
-	tauG2 := make([]*bn256.G2, l) //h^s, h^s^2...
+	g, h := curve.G1Generator(), curve.G2Generator()
+	tauG1 := make([]primitives.G1, l) //g^s, g^s^2,...
+	tauG2 := make([]primitives.G2, l) //h^s, h^s^2...
+	sPow := curve.ScalarField().NewElement(1)
 	for i := 0; i < l; i++ {
-		sPow := new(mod.Int).Exp(s, big.NewInt(int64(i)))
-		tauG1[i] = new(bn256.G1).ScalarBaseMult(&sPow.(*mod.Int).V)
-		tauG2[i] = new(bn256.G2).ScalarBaseMult(&sPow.(*mod.Int).V)
+		tauG1[i] = g.ScalarMult(&sPow.V)
+		tauG2[i] = h.ScalarMult(&sPow.V)
+		sPow = new(mod.Int).Mul(sPow, s).(*mod.Int)
 	}
 
-	return &TrustedSetup{tauG1, tauG2}, nil
+	return &TrustedSetup{curve, tauG1, tauG2}, nil
 }
 
 // Commit generates the commitment to the polynomial p(x)
-func Commit(ts *TrustedSetup, p *primitives.Polynomial) *bn256.G1 {
+func Commit(ts *TrustedSetup, p *primitives.Polynomial) primitives.G1 {
 	c := evaluateG1(ts, p.Coefficient)
 	return c
 }
 
-func evaluateG1(ts *TrustedSetup, p []*mod.Int) *bn256.G1 {
-	c := new(bn256.G1).ScalarMult(ts.Tau1[0], &p[0].V)
-	for i := 1; i < len(p); i++ {
-		sp := new(bn256.G1).ScalarMult(ts.Tau1[i], &p[i].V)
-		c = new(bn256.G1).Add(c, sp)
-	}
-	return c
+func evaluateG1(ts *TrustedSetup, p []*mod.Int) primitives.G1 {
+	return primitives.MultiScalarMultG1(ts.Curve, ts.Tau1[:len(p)], primitives.Scalars(p))
 }
 
-//nolint:deadcode,unused
-func evaluateG2(ts *TrustedSetup, p []*mod.Int) *bn256.G2 {
-	c := new(bn256.G2).ScalarMult(ts.Tau2[0], &p[0].V)
-	for i := 1; i < len(p); i++ {
-		sp := new(bn256.G2).ScalarMult(ts.Tau2[i], &p[i].V)
-		c = new(bn256.G2).Add(c, sp)
-	}
-	return c
+func evaluateG2(ts *TrustedSetup, p []*mod.Int) primitives.G2 {
+	return primitives.MultiScalarMultG2(ts.Curve, ts.Tau2[:len(p)], primitives.Scalars(p))
 }
 
 // EvaluationProof generates the evaluation proof
-func EvaluationProof(ts *TrustedSetup, p *primitives.Polynomial, z, y *mod.Int) (*bn256.G1, error) {
+func EvaluationProof(ts *TrustedSetup, p *primitives.Polynomial, z, y *mod.Int) (primitives.G1, error) {
 	n := new(primitives.Polynomial).Sub(p, new(primitives.Polynomial).Init([]*mod.Int{y})) // p-y
 
 	// n := p // we can omit y (p(z))
-	d := new(primitives.Polynomial).Init([]*mod.Int{new(mod.Int).Neg(z).(*mod.Int), mod.NewInt64(1, primitives.Q)}) // x-z
-	q, rem := new(primitives.Polynomial).Div(n, d)
-	if new(primitives.Polynomial).Cmp(rem, new(primitives.Polynomial).InitFromZerosArray(rem.Degree)) {
+	// q(x) = n(x) / (x-z), by synthetic division
+	q, rem := new(primitives.Polynomial).DivByLinear(n, z)
+	if rem.Nonzero() {
 		return nil,
-			fmt.Errorf("remainder should be 0, instead is %s", rem.ToString())
+			fmt.Errorf("remainder should be 0, instead is %s", rem.String())
 	}
 
 	// proof: e = [q(t)]₁
@@ -81,19 +76,21 @@ func EvaluationProof(ts *TrustedSetup, p *primitives.Polynomial, z, y *mod.Int)
 }
 
 // Verify computes the KZG commitment verification
-func Verify(ts *TrustedSetup, c, proof *bn256.G1, z, y *mod.Int) bool {
+func Verify(ts *TrustedSetup, c, proof primitives.G1, z, y *mod.Int) bool {
+	if c == nil || proof == nil || z == nil || y == nil || len(ts.Tau2) < 2 {
+		return false
+	}
+	h := ts.Curve.G2Generator() // H ∈ 𝔾₂
+
 	// [t]₂ - [z]₂
-	sz := new(bn256.G2).Add(ts.Tau2[1], new(bn256.G2).Neg(new(bn256.G2).ScalarBaseMult(&z.V)))
+	sz := ts.Tau2[1].Add(h.ScalarMult(&z.V).Neg())
 
 	// c - [y]₁
-	cy := new(bn256.G1).Add(c, new(bn256.G1).ScalarBaseMult(&new(mod.Int).Neg(y).(*mod.Int).V))
-	h := new(bn256.G2).ScalarBaseMult(big.NewInt(1)) // H ∈ 𝔾₂
+	cy := c.Add(ts.Curve.G1Generator().ScalarMult(&y.V).Neg())
 
 	// e(proof, [t]₂ - [z]₂) == e(c - [y]₁, H)
-	e1 := bn256.Pair(proof, sz)
-	e2 := bn256.Pair(cy, h)
-
-	return e1.String() == e2.String()
+	// checked as e(proof, [t]₂ - [z]₂) · e(-(c - [y]₁), H) == 1
+	return primitives.PairingCheck([]primitives.G1{proof, cy.Neg()}, []primitives.G2{sz, h})
 }
 
 //
@@ -101,7 +98,7 @@ func Verify(ts *TrustedSetup, c, proof *bn256.G1, z, y *mod.Int) bool {
 //
 
 // EvaluationBatchProof generates the evalutation proof for the given list of points
-func EvaluationBatchProof(ts *TrustedSetup, p *primitives.Polynomial, zs, ys []*mod.Int) (*bn256.G1, error) {
+func EvaluationBatchProof(ts *TrustedSetup, p *primitives.Polynomial, zs, ys []*mod.Int) (primitives.G1, error) {
 	if len(zs) != len(ys) {
 		return nil, fmt.Errorf("len(zs)!=len(ys), %d!=%d", len(zs), len(ys))
 	}
@@ -112,11 +109,15 @@ func EvaluationBatchProof(ts *TrustedSetup, p *primitives.Polynomial, zs, ys []*
 			p.Degree, len(zs))
 	}
 
+	tree, err := primitives.NewSubproductTree(zs)
+	if err != nil {
+		return nil, err
+	}
 	// z(x) = (x-z0)(x-z1)...(x-zn)
-	z := new(primitives.Polynomial).Zero(zs)
+	z := tree.Root()
 
 	// I(x) = Lagrange interpolation through (z0, y0), (z1, y1), ...
-	i, err := new(primitives.Polynomial).LagrangeInterpolation(zs, ys)
+	i, err := tree.Interpolate(ys)
 	if err != nil {
 		return nil, err
 	}
@@ -124,7 +125,7 @@ func EvaluationBatchProof(ts *TrustedSetup, p *primitives.Polynomial, zs, ys []*
 	// q(x) = ( p(x) - I(x) ) / z(x)
 	pMinusI := new(primitives.Polynomial).Sub(p, i)
 	q, rem := new(primitives.Polynomial).Div(pMinusI, z)
-	if new(primitives.Polynomial).Cmp(rem, new(primitives.Polynomial).InitFromZerosArray(rem.Degree)) {
+	if !rem.IsZero() {
 		return nil,
 			fmt.Errorf("remainder should be 0, instead is %s", rem.ToString())
 	}
@@ -135,13 +136,21 @@ func EvaluationBatchProof(ts *TrustedSetup, p *primitives.Polynomial, zs, ys []*
 }
 
 // VerifyBatchProof computes the KZG batch proof commitment verification
-func VerifyBatchProof(ts *TrustedSetup, c, proof *bn256.G1, zs, ys []*mod.Int) bool {
+func VerifyBatchProof(ts *TrustedSetup, c, proof primitives.G1, zs, ys []*mod.Int) bool {
+	// z(x) has len(zs)+1 coefficients to commit in 𝔾₂
+	if c == nil || proof == nil || len(zs) != len(ys) || len(zs) >= len(ts.Tau2) || len(zs) > len(ts.Tau1) {
+		return false
+	}
+	tree, err := primitives.NewSubproductTree(zs)
+	if err != nil {
+		return false
+	}
 	// [z(s)]₂
-	z := new(primitives.Polynomial).Zero(zs)
+	z := tree.Root()
 	zG2 := evaluateG2(ts, z.Coefficient) // [z(t)]₂ = z(t) G ∈ 𝔾₂
 
 	// I(x) = Lagrange interpolation through (z0, y0), (z1, y1), ...
-	i, err := new(primitives.Polynomial).LagrangeInterpolation(zs, ys)
+	i, err := tree.Interpolate(ys)
 	if err != nil {
 		return false
 	}
@@ -149,13 +158,11 @@ func VerifyBatchProof(ts *TrustedSetup, c, proof *bn256.G1, zs, ys []*mod.Int) b
 	iG1 := evaluateG1(ts, i.Coefficient) // [i(t)]₁ = i(t) G ∈ 𝔾₁
 
 	// c - [i(t)]₁
-	iG1Neg := new(bn256.G1).Neg(iG1)
-	ciG1 := new(bn256.G1).Add(c, iG1Neg)
+	ciG1 := c.Add(iG1.Neg())
 
-	h := new(bn256.G2).ScalarBaseMult(big.NewInt(1)) // H ∈ 𝔾₂
+	h := ts.Curve.G2Generator() // H ∈ 𝔾₂
 
 	// e(proof, [z(t)]₂) == e(c - [I(t)]₁, H)
-	e1 := bn256.Pair(proof, zG2)
-	e2 := bn256.Pair(ciG1, h)
-	return e1.String() == e2.String()
+	// checked as e(proof, [z(t)]₂) · e(-(c - [I(t)]₁), H) == 1
+	return primitives.PairingCheck([]primitives.G1{proof, ciG1.Neg()}, []primitives.G2{zG2, h})
 }
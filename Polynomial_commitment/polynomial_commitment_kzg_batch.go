@@ -0,0 +1,75 @@
+package Polynomial_commitment
+
+import (
+	"commitment/primitives"
+	"github.com/drand/kyber/group/mod"
+	"math/big"
+)
+
+// Opening is the claim that the polynomial committed in C evaluates to Y at
+// Z, together with its evaluation proof
+type Opening struct {
+	C     primitives.G1
+	Proof primitives.G1
+	Z, Y  *mod.Int
+}
+
+// BatchVerify checks many independent openings, of different commitments at
+// different points, with one multi-pairing. Each opening verifies
+//
+//	e(πᵢ, [t]₂) == e(cᵢ - [yᵢ]₁ + zᵢπᵢ, H)
+//
+// so for random rᵢ it is enough to check
+//
+//	e(Σ rᵢπᵢ, [t]₂) · e(-Σ rᵢ(cᵢ - [yᵢ]₁ + zᵢπᵢ), H) == 1
+//
+// which fails, except with negligible probability, if any opening is invalid.
+func BatchVerify(ts *TrustedSetup, openings []*Opening) bool {
+	if len(openings) == 0 {
+		return true
+	}
+	f := ts.Curve.ScalarField()
+	ps := make([]primitives.G1, 0, 2*len(openings)+1)
+	ks := make([]*big.Int, 0, 2*len(openings)+1)
+	proofs := make([]primitives.G1, len(openings))
+	rs := make([]*big.Int, len(openings))
+	ry := f.NewElement(0)
+	for i, o := range openings {
+		r, err := f.Rand()
+		if err != nil {
+			return false
+		}
+		proofs[i], rs[i] = o.Proof, &r.V
+		// rᵢcᵢ + rᵢzᵢπᵢ
+		ps = append(ps, o.C, o.Proof)
+		ks = append(ks, &r.V, &new(mod.Int).Mul(r, o.Z).(*mod.Int).V)
+		ry = new(mod.Int).Add(ry, new(mod.Int).Mul(r, o.Y)).(*mod.Int)
+	}
+	// - (Σ rᵢyᵢ)·G
+	ps = append(ps, ts.Curve.G1Generator())
+	ks = append(ks, &new(mod.Int).Neg(ry).(*mod.Int).V)
+
+	lhs := primitives.MultiScalarMultG1(ts.Curve, proofs, rs)
+	rhs := primitives.MultiScalarMultG1(ts.Curve, ps, ks)
+	return primitives.PairingCheck(
+		[]primitives.G1{lhs, rhs.Neg()},
+		[]primitives.G2{ts.Tau2[1], ts.Curve.G2Generator()})
+}
+
+// BatchVerifyWithBisection batch verifies the openings and, when the batch is
+// rejected, bisects it to find the invalid ones. It returns the indexes of the
+// invalid openings, which is empty when all the openings are valid.
+func BatchVerifyWithBisection(ts *TrustedSetup, openings []*Opening) []int {
+	return bisect(ts, openings, 0)
+}
+
+func bisect(ts *TrustedSetup, openings []*Opening, offset int) []int {
+	if BatchVerify(ts, openings) {
+		return []int{}
+	}
+	if len(openings) == 1 {
+		return []int{offset}
+	}
+	mid := len(openings) / 2
+	return append(bisect(ts, openings[:mid], offset), bisect(ts, openings[mid:], offset+mid)...)
+}
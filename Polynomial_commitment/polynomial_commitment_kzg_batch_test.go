@@ -0,0 +1,51 @@
+package Polynomial_commitment
+
+import (
+	"commitment/primitives"
+	"github.com/drand/kyber/group/mod"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func randomOpenings(t *testing.T, ts *TrustedSetup, n int) []*Opening {
+	f := ts.Curve.ScalarField()
+	openings := make([]*Opening, n)
+	for i := 0; i < n; i++ {
+		coeffs := make([]*mod.Int, len(ts.Tau1))
+		for j := range coeffs {
+			coeffs[j], _ = f.Rand()
+		}
+		p := new(primitives.Polynomial).Init(coeffs)
+		z, _ := f.Rand()
+		y := p.Eval(z)
+		proof, err := EvaluationProof(ts, p, z, y)
+		assert.Nil(t, err)
+		openings[i] = &Opening{Commit(ts, p), proof, z, y}
+	}
+	return openings
+}
+
+func TestBatchVerify(t *testing.T) {
+	ts, err := NewTrustedSetup(8)
+	assert.Nil(t, err)
+	openings := randomOpenings(t, ts, 10)
+
+	assert.True(t, BatchVerify(ts, openings))
+	assert.Empty(t, BatchVerifyWithBisection(ts, openings))
+
+	// corrupt two openings
+	openings[3].Y = new(mod.Int).Add(openings[3].Y, mod.NewInt64(1, primitives.Q)).(*mod.Int)
+	openings[7].Proof = openings[6].Proof
+	assert.False(t, BatchVerify(ts, openings))
+	assert.Equal(t, []int{3, 7}, BatchVerifyWithBisection(ts, openings))
+}
+
+func TestBatchVerifyBLS12381(t *testing.T) {
+	ts, err := NewTrustedSetupOver(primitives.CurveBLS12381, 4)
+	assert.Nil(t, err)
+	openings := randomOpenings(t, ts, 3)
+	assert.True(t, BatchVerify(ts, openings))
+
+	openings[0].Z = openings[1].Z
+	assert.Equal(t, []int{0}, BatchVerifyWithBisection(ts, openings))
+}
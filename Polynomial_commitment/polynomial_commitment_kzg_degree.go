@@ -0,0 +1,87 @@
+package Polynomial_commitment
+
+import (
+	"commitment/primitives"
+	"fmt"
+	"github.com/drand/kyber/group/mod"
+)
+
+//
+// Degree bound proofs
+//
+
+// DegreeBoundProof proves that the polynomial committed in c has degree at
+// most d, with D = len(Tau1)-1 the maximum degree of the setup
+type DegreeBoundProof struct {
+	Shifted primitives.G1 // [t^{D-d}·p(t)]₁
+	D       int           // the bound d
+}
+
+// CommitWithDegreeBound commits to p(x) and proves that its degree is at
+// most d. The shifted commitment [t^{D-d}·p(t)]₁ can only be computed with
+// the powers of the setup when deg(p) ≤ d.
+func CommitWithDegreeBound(ts *TrustedSetup, p *primitives.Polynomial, d int) (primitives.G1, *DegreeBoundProof, error) {
+	maxDegree := len(ts.Tau1) - 1
+	if d < 0 || d > maxDegree {
+		return nil, nil, fmt.Errorf("the degree bound must be in [0, %d], got %d", maxDegree, d)
+	}
+	if maxDegree-d >= len(ts.Tau2) {
+		return nil, nil, fmt.Errorf("the trusted setup has %d points of G2, %d are needed", len(ts.Tau2), maxDegree-d+1)
+	}
+	deg := degree(p)
+	if deg > d {
+		return nil, nil, fmt.Errorf("polynomial p(x) has degree %d, bigger than the bound %d", deg, d)
+	}
+	coefficients := p.Coefficient[:deg+1]
+	c := evaluateG1(ts, coefficients)
+	shifted := primitives.MultiScalarMultG1(ts.Curve, ts.Tau1[maxDegree-d:maxDegree-d+len(coefficients)], primitives.Scalars(coefficients))
+	return c, &DegreeBoundProof{shifted, d}, nil
+}
+
+// VerifyDegreeBound verifies that the polynomial committed in c has degree at
+// most proof.D: e(c, [t^{D-d}]₂) == e([t^{D-d}·p(t)]₁, H)
+func VerifyDegreeBound(ts *TrustedSetup, c primitives.G1, proof *DegreeBoundProof) bool {
+	shift := len(ts.Tau1) - 1 - proof.D
+	if proof.D < 0 || shift < 0 || shift >= len(ts.Tau2) {
+		return false
+	}
+	return primitives.PairingCheck(
+		[]primitives.G1{c, proof.Shifted.Neg()},
+		[]primitives.G2{ts.Tau2[shift], ts.Curve.G2Generator()})
+}
+
+// VerifyWithDegreeBound verifies the evaluation proof of p(z) = y together
+// with the degree bound of p, with a single multi-pairing. For a random r
+//
+//	e(proof, [t]₂ - [z]₂) · e(r·c, [t^{D-d}]₂) · e(-(c - [y]₁) - r·[t^{D-d}·p(t)]₁, H) == 1
+func VerifyWithDegreeBound(ts *TrustedSetup, c, proof primitives.G1, bound *DegreeBoundProof, z, y *mod.Int) bool {
+	shift := len(ts.Tau1) - 1 - bound.D
+	if bound.D < 0 || shift < 0 || shift >= len(ts.Tau2) {
+		return false
+	}
+	r, err := ts.Curve.ScalarField().Rand()
+	if err != nil {
+		return false
+	}
+	h := ts.Curve.G2Generator()
+
+	// [t]₂ - [z]₂
+	sz := ts.Tau2[1].Add(h.ScalarMult(&z.V).Neg())
+	// c - [y]₁ + r·[t^{D-d}·p(t)]₁
+	cy := c.Add(ts.Curve.G1Generator().ScalarMult(&y.V).Neg()).Add(bound.Shifted.ScalarMult(&r.V))
+
+	return primitives.PairingCheck(
+		[]primitives.G1{proof, c.ScalarMult(&r.V), cy.Neg()},
+		[]primitives.G2{sz, ts.Tau2[shift], h})
+}
+
+// degree returns the degree of p ignoring the leading zero coefficients, 0
+// for the zero polynomial
+func degree(p *primitives.Polynomial) int {
+	for i := len(p.Coefficient) - 1; i > 0; i-- {
+		if p.Coefficient[i].Nonzero() {
+			return i
+		}
+	}
+	return 0
+}
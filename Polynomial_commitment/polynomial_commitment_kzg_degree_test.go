@@ -0,0 +1,55 @@
+package Polynomial_commitment
+
+import (
+	"commitment/primitives"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCommitWithDegreeBound(t *testing.T) {
+	ts, err := NewTrustedSetup(10)
+	assert.Nil(t, err)
+	// degree 4
+	p := randPolynomials(ts, 1, 5)[0]
+
+	c, proof, err := CommitWithDegreeBound(ts, p, 6)
+	assert.Nil(t, err)
+	assert.True(t, c.Equal(Commit(ts, p)))
+	assert.True(t, VerifyDegreeBound(ts, c, proof))
+
+	// the shifted commitment only holds for its bound
+	proof.D = 5
+	assert.False(t, VerifyDegreeBound(ts, c, proof))
+	proof.D = 6
+	assert.False(t, VerifyDegreeBound(ts, c.Add(c), proof))
+
+	_, _, err = CommitWithDegreeBound(ts, p, 3)
+	assert.NotNil(t, err)
+	_, _, err = CommitWithDegreeBound(ts, p, 10)
+	assert.NotNil(t, err)
+
+	// the degree bound is tight
+	_, proof, err = CommitWithDegreeBound(ts, p, 4)
+	assert.Nil(t, err)
+	assert.True(t, VerifyDegreeBound(ts, c, proof))
+}
+
+func TestVerifyWithDegreeBound(t *testing.T) {
+	for _, curve := range []primitives.Curve{primitives.CurveBN254, primitives.CurveBLS12381} {
+		ts, err := NewTrustedSetupOver(curve, 8)
+		assert.Nil(t, err)
+		p := randPolynomials(ts, 1, 4)[0]
+		c, bound, err := CommitWithDegreeBound(ts, p, 5)
+		assert.Nil(t, err)
+
+		z := curve.ScalarField().NewElement(11)
+		y := p.Eval(z)
+		proof, err := EvaluationProof(ts, p, z, y)
+		assert.Nil(t, err)
+		assert.True(t, VerifyWithDegreeBound(ts, c, proof, bound, z, y), curve.Name())
+		assert.False(t, VerifyWithDegreeBound(ts, c, proof, bound, z, z), curve.Name())
+
+		bound.D = 4
+		assert.False(t, VerifyWithDegreeBound(ts, c, proof, bound, z, y), curve.Name())
+	}
+}
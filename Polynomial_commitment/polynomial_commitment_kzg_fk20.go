@@ -0,0 +1,113 @@
+package Polynomial_commitment
+
+import (
+	"commitment/primitives"
+	"fmt"
+	"github.com/drand/kyber/group/mod"
+	"math/big"
+)
+
+//
+// FK20: all the proofs over a roots of unity domain in O(n log n)
+// (Feist, Khovratovich, https://eprint.iacr.org/2023/033)
+//
+
+// FK20 computes the proofs of a polynomial for all the cosets ωᵏ·⟨ωᵐ⟩ of
+// size l of the domain ⟨ω⟩ of size n, with m = n/l. For l = 1 the cosets are
+// the single points ωᵏ.
+//
+// The proof for the coset k is the commitment to the quotient by xˡ - aₖ,
+// aₖ = ωᵏˡ, which is Σₜ aₖᵗ·Hₜ with Hₜ = Σᵢ f_{i+(t+1)l}·[tⁱ]₁. The vector H
+// is a Toeplitz matrix-vector product, computed with FFTs in 𝔾₁, and the
+// proofs are the FFT of H.
+type FK20 struct {
+	ts    *TrustedSetup
+	N     int      // size of the domain
+	L     int      // size of the cosets
+	Omega *mod.Int // generator of the domain
+	// xExt[r] is the FFT of size 2m of ([t^{r+l(m-1)}]₁, ..., [t^{r+l}]₁, [t^r]₁, 0, ..., 0)
+	xExt [][]primitives.G1
+}
+
+// NewFK20 precomputes the setup dependent FFTs for a domain of size n and
+// cosets of size l, both powers of two
+func NewFK20(ts *TrustedSetup, n, l int) (*FK20, error) {
+	if l <= 0 || n < l || n%l != 0 {
+		return nil, fmt.Errorf("invalid coset size %d for a domain of size %d", l, n)
+	}
+	omega, err := ts.Curve.ScalarField().RootOfUnity(n)
+	if err != nil {
+		return nil, err
+	}
+	m := n / l
+	zero := primitives.G1Zero(ts.Curve)
+	xExt := make([][]primitives.G1, l)
+	for r := 0; r < l; r++ {
+		x := make([]primitives.G1, 2*m)
+		for w := 0; w < 2*m; w++ {
+			x[w] = zero
+		}
+		for u := 0; u < m; u++ {
+			if i := r + l*u; i < len(ts.Tau1) {
+				x[m-1-u] = ts.Tau1[i]
+			}
+		}
+		if xExt[r], err = primitives.NTTG1(x); err != nil {
+			return nil, err
+		}
+	}
+	return &FK20{ts, n, l, omega, xExt}, nil
+}
+
+// ComputeProofs returns the n/l proofs of p, the k-th one proving the
+// evaluations of p over the coset CosetPoints(k). p must have at most n
+// coefficients.
+func (fk *FK20) ComputeProofs(p *primitives.Polynomial) ([]primitives.G1, error) {
+	if p.Degree > fk.N {
+		return nil, fmt.Errorf("polynomial p(x) has %d coefficients, more than the domain size %d", p.Degree, fk.N)
+	}
+	if p.Degree > len(fk.ts.Tau1) {
+		return nil, fmt.Errorf("polynomial p(x) has %d coefficients, the trusted setup only %d", p.Degree, len(fk.ts.Tau1))
+	}
+	f := fk.ts.Curve.ScalarField()
+	m := fk.N / fk.L
+
+	// Y = Σᵣ FFT(xᵣ) ⊙ FFT(cᵣ), with cᵣ = (f_r, f_{r+l}, f_{r+2l}, ...)
+	y := make([]primitives.G1, 2*m)
+	for i := range y {
+		y[i] = primitives.G1Zero(fk.ts.Curve)
+	}
+	for r := 0; r < fk.L; r++ {
+		c := make([]*mod.Int, 2*m)
+		for v := range c {
+			if i := r + fk.L*v; i < p.Degree {
+				c[v] = p.Coefficient[i]
+			} else {
+				c[v] = f.NewElement(0)
+			}
+		}
+		cExt, err := primitives.NTT(c, 2*m)
+		if err != nil {
+			return nil, err
+		}
+		for i := range y {
+			y[i] = y[i].Add(fk.xExt[r][i].ScalarMult(&cExt[i].V))
+		}
+	}
+	// the circular convolution holds H in its upper half
+	h, err := primitives.InverseNTTG1(y)
+	if err != nil {
+		return nil, err
+	}
+	return primitives.NTTG1(h[m:])
+}
+
+// CosetPoints returns the points ωᵏ, ωᵏ⁺ᵐ, ..., ωᵏ⁺⁽ˡ⁻¹⁾ᵐ proven by the k-th proof
+func (fk *FK20) CosetPoints(k int) []*mod.Int {
+	m := fk.N / fk.L
+	zs := make([]*mod.Int, fk.L)
+	for j := range zs {
+		zs[j] = new(mod.Int).Exp(fk.Omega, big.NewInt(int64(k+j*m))).(*mod.Int)
+	}
+	return zs
+}
@@ -0,0 +1,67 @@
+package Polynomial_commitment
+
+import (
+	"commitment/primitives"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFK20_SinglePointProofs(t *testing.T) {
+	ts, err := NewTrustedSetup(16)
+	assert.Nil(t, err)
+	p := randPolynomials(ts, 1, 13)[0]
+	c := Commit(ts, p)
+
+	fk, err := NewFK20(ts, 16, 1)
+	assert.Nil(t, err)
+	proofs, err := fk.ComputeProofs(p)
+	assert.Nil(t, err)
+	assert.Equal(t, 16, len(proofs))
+
+	for k := range proofs {
+		z := fk.CosetPoints(k)[0]
+		y := p.Eval(z)
+		proof, err := EvaluationProof(ts, p, z, y)
+		assert.Nil(t, err)
+		assert.True(t, proof.Equal(proofs[k]), "proof %d", k)
+	}
+	assert.True(t, Verify(ts, c, proofs[5], fk.CosetPoints(5)[0], p.Eval(fk.CosetPoints(5)[0])))
+}
+
+func TestFK20_MultiPointProofs(t *testing.T) {
+	ts, err := NewTrustedSetup(16)
+	assert.Nil(t, err)
+	p := randPolynomials(ts, 1, 16)[0]
+	c := Commit(ts, p)
+
+	fk, err := NewFK20(ts, 16, 4)
+	assert.Nil(t, err)
+	proofs, err := fk.ComputeProofs(p)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(proofs))
+
+	for k := range proofs {
+		zs := fk.CosetPoints(k)
+		ys := p.BatchEval(zs)
+		assert.True(t, VerifyBatchProof(ts, c, proofs[k], zs, ys), "coset %d", k)
+	}
+	zs := fk.CosetPoints(1)
+	assert.False(t, VerifyBatchProof(ts, c, proofs[0], zs, p.BatchEval(zs)))
+
+	_, err = fk.ComputeProofs(randPolynomials(ts, 1, 17)[0])
+	assert.NotNil(t, err)
+	_, err = NewFK20(ts, 16, 3)
+	assert.NotNil(t, err)
+}
+
+func TestFK20_BLS12381(t *testing.T) {
+	ts, err := NewTrustedSetupOver(primitives.CurveBLS12381, 4)
+	assert.Nil(t, err)
+	p := randPolynomials(ts, 1, 4)[0]
+	fk, err := NewFK20(ts, 4, 2)
+	assert.Nil(t, err)
+	proofs, err := fk.ComputeProofs(p)
+	assert.Nil(t, err)
+	zs := fk.CosetPoints(1)
+	assert.True(t, VerifyBatchProof(ts, Commit(ts, p), proofs[1], zs, p.BatchEval(zs)))
+}
@@ -0,0 +1,101 @@
+package Polynomial_commitment
+
+import (
+	"commitment/primitives"
+	"fmt"
+	"github.com/drand/kyber/group/mod"
+)
+
+//
+// Hiding commitments, PolyCommit_Ped of Kate, Zaverucha, Goldberg
+//
+
+// HidingTrustedSetup extends the trusted setup with the powers of t on a
+// second generator h of 𝔾₁, whose discrete logarithm in base G is unknown
+type HidingTrustedSetup struct {
+	*TrustedSetup
+	TauH []primitives.G1 // h, [t]h, [t²]h, ...
+}
+
+// HidingProof is the evaluation proof of a hiding commitment
+type HidingProof struct {
+	W primitives.G1 // [ψ(t)]₁ + [ψ̂(t)]h
+	R *mod.Int      // r(z), the evaluation of the blinding polynomial
+}
+
+// NewHidingTrustedSetup returns a new hiding trusted setup over BN254. Both t
+// and the discrete logarithm of h are toxic waste.
+func NewHidingTrustedSetup(l int) (*HidingTrustedSetup, error) {
+	return NewHidingTrustedSetupOver(primitives.CurveBN254, l)
+}
+
+// NewHidingTrustedSetupOver returns a new hiding trusted setup over the curve
+func NewHidingTrustedSetupOver(curve primitives.Curve, l int) (*HidingTrustedSetup, error) {
+	f := curve.ScalarField()
+	s, err := f.Rand()
+	if err != nil {
+		return nil, err
+	}
+	alpha, err := f.Rand()
+	if err != nil {
+		return nil, err
+	}
+
+	g, h := curve.G1Generator(), curve.G2Generator()
+	tauG1 := make([]primitives.G1, l)
+	tauG2 := make([]primitives.G2, l)
+	tauH := make([]primitives.G1, l)
+	sPow := f.NewElement(1)
+	for i := 0; i < l; i++ {
+		tauG1[i] = g.ScalarMult(&sPow.V)
+		tauG2[i] = h.ScalarMult(&sPow.V)
+		tauH[i] = g.ScalarMult(&new(mod.Int).Mul(sPow, alpha).(*mod.Int).V)
+		sPow = new(mod.Int).Mul(sPow, s).(*mod.Int)
+	}
+	return &HidingTrustedSetup{&TrustedSetup{curve, tauG1, tauG2}, tauH}, nil
+}
+
+// HidingCommit commits to p(x) as c = [p(t)]₁ + [r(t)]h for a random blinding
+// polynomial r(x) of the same degree, which is returned to open c later
+func HidingCommit(hts *HidingTrustedSetup, p *primitives.Polynomial) (primitives.G1, *primitives.Polynomial, error) {
+	f := hts.Curve.ScalarField()
+	coeffs := make([]*mod.Int, p.Degree)
+	for i := range coeffs {
+		r, err := f.Rand()
+		if err != nil {
+			return nil, nil, err
+		}
+		coeffs[i] = r
+	}
+	r := new(primitives.Polynomial).Init(coeffs)
+	return evaluateG1(hts.TrustedSetup, p.Coefficient).Add(evaluateH(hts, r.Coefficient)), r, nil
+}
+
+// HidingEvaluationProof proves p(z) = y for the commitment of p blinded by r
+func HidingEvaluationProof(hts *HidingTrustedSetup, p, r *primitives.Polynomial, z, y *mod.Int) (*HidingProof, error) {
+	// ψ(x) = (p(x) - y) / (x - z)
+	psi, rem := new(primitives.Polynomial).DivByLinear(p, z)
+	if !rem.Equal(y) {
+		return nil, fmt.Errorf("p(z) is %s, not %s", rem.String(), y.String())
+	}
+	// ψ̂(x) = (r(x) - r(z)) / (x - z)
+	psiHat, rz := new(primitives.Polynomial).DivByLinear(r, z)
+	w := evaluateG1(hts.TrustedSetup, psi.Coefficient).Add(evaluateH(hts, psiHat.Coefficient))
+	return &HidingProof{w, rz}, nil
+}
+
+// HidingVerify verifies the proof of p(z) = y for the hiding commitment c:
+// e(c, H) == e(w, [t]₂ - [z]₂) · e([y]₁ + r(z)h, H)
+func HidingVerify(hts *HidingTrustedSetup, c primitives.G1, proof *HidingProof, z, y *mod.Int) bool {
+	h := hts.Curve.G2Generator()
+	// [t]₂ - [z]₂
+	sz := hts.Tau2[1].Add(h.ScalarMult(&z.V).Neg())
+	// c - [y]₁ - r(z)h
+	cy := c.Add(hts.Curve.G1Generator().ScalarMult(&y.V).Add(hts.TauH[0].ScalarMult(&proof.R.V)).Neg())
+
+	return primitives.PairingCheck([]primitives.G1{cy, proof.W.Neg()}, []primitives.G2{h, sz})
+}
+
+func evaluateH(hts *HidingTrustedSetup, p []*mod.Int) primitives.G1 {
+	return primitives.MultiScalarMultG1(hts.Curve, hts.TauH[:len(p)], primitives.Scalars(p))
+}
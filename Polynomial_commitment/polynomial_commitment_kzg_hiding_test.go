@@ -0,0 +1,51 @@
+package Polynomial_commitment
+
+import (
+	"commitment/primitives"
+	"github.com/drand/kyber/group/mod"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestHidingCommit(t *testing.T) {
+	hts, err := NewHidingTrustedSetup(4)
+	assert.Nil(t, err)
+	// p(x) = x^3 + x + 5
+	p := new(primitives.Polynomial).Init([]*mod.Int{
+		mod.NewInt64(5, primitives.Q),
+		mod.NewInt64(1, primitives.Q),
+		mod.NewInt64(0, primitives.Q),
+		mod.NewInt64(1, primitives.Q),
+	})
+
+	c1, r, err := HidingCommit(hts, p)
+	assert.Nil(t, err)
+	// two commitments to the same polynomial differ
+	c2, _, err := HidingCommit(hts, p)
+	assert.Nil(t, err)
+	assert.False(t, c1.Equal(c2))
+	assert.False(t, c1.Equal(Commit(hts.TrustedSetup, p)))
+
+	z := mod.NewInt64(3, primitives.Q)
+	y := mod.NewInt64(35, primitives.Q)
+	proof, err := HidingEvaluationProof(hts, p, r, z, y)
+	assert.Nil(t, err)
+	assert.True(t, HidingVerify(hts, c1, proof, z, y))
+	assert.False(t, HidingVerify(hts, c2, proof, z, y))
+	assert.False(t, HidingVerify(hts, c1, proof, z, mod.NewInt64(36, primitives.Q)))
+
+	_, err = HidingEvaluationProof(hts, p, r, z, mod.NewInt64(36, primitives.Q))
+	assert.NotNil(t, err)
+}
+
+func TestHidingCommitBLS12381(t *testing.T) {
+	hts, err := NewHidingTrustedSetupOver(primitives.CurveBLS12381, 3)
+	assert.Nil(t, err)
+	p := randPolynomials(hts.TrustedSetup, 1, 3)[0]
+	c, r, err := HidingCommit(hts, p)
+	assert.Nil(t, err)
+	z := primitives.BLS12381.NewElement(7)
+	proof, err := HidingEvaluationProof(hts, p, r, z, p.Eval(z))
+	assert.Nil(t, err)
+	assert.True(t, HidingVerify(hts, c, proof, z, p.Eval(z)))
+}
@@ -0,0 +1,221 @@
+package Polynomial_commitment
+
+import (
+	"commitment/primitives"
+	"fmt"
+	"github.com/drand/kyber/group/mod"
+	"math/big"
+)
+
+//
+// Many polynomials opened at one point
+//
+
+// MultiEvaluationProof opens the polynomials ps, committed in cs, at the same
+// point z with a single proof. With γ derived from the transcript, it is the
+// evaluation proof of Σ γⁱpᵢ(x) at z.
+func MultiEvaluationProof(ts *TrustedSetup, ps []*primitives.Polynomial, cs []primitives.G1, z *mod.Int, ys []*mod.Int, tr *primitives.Transcript) (primitives.G1, error) {
+	if len(ps) != len(cs) || len(ps) != len(ys) {
+		return nil, fmt.Errorf("len(ps), len(cs) and len(ys) differ: %d, %d, %d", len(ps), len(cs), len(ys))
+	}
+	gamma := multiEvaluationChallenge(ts, tr, cs, z, ys)
+
+	// q(x) = Σ γⁱ(pᵢ(x) - yᵢ) / (x - z)
+	f := ts.Curve.ScalarField()
+	n := new(primitives.Polynomial).InitFromZerosArrayOver(f, 1)
+	gammaPow := f.NewElement(1)
+	for i := range ps {
+		pi := new(primitives.Polynomial).Sub(ps[i], new(primitives.Polynomial).Init([]*mod.Int{ys[i]}))
+		n = new(primitives.Polynomial).Add(n, scale(pi, gammaPow))
+		gammaPow = new(mod.Int).Mul(gammaPow, gamma).(*mod.Int)
+	}
+	q, rem := new(primitives.Polynomial).DivByLinear(n, z)
+	if rem.Nonzero() {
+		return nil, fmt.Errorf("remainder should be 0, instead is %s", rem.String())
+	}
+	return evaluateG1(ts, q.Coefficient), nil
+}
+
+// VerifyMultiEvaluation verifies a MultiEvaluationProof, checking the proof of
+// Σ γⁱcᵢ opening to Σ γⁱyᵢ at z
+func VerifyMultiEvaluation(ts *TrustedSetup, cs []primitives.G1, proof primitives.G1, z *mod.Int, ys []*mod.Int, tr *primitives.Transcript) bool {
+	if len(cs) != len(ys) || len(cs) == 0 {
+		return false
+	}
+	gamma := multiEvaluationChallenge(ts, tr, cs, z, ys)
+
+	f := ts.Curve.ScalarField()
+	ks := make([]*big.Int, len(cs))
+	y := f.NewElement(0)
+	gammaPow := f.NewElement(1)
+	for i := range cs {
+		ks[i] = &gammaPow.V
+		y = new(mod.Int).Add(y, new(mod.Int).Mul(gammaPow, ys[i])).(*mod.Int)
+		gammaPow = new(mod.Int).Mul(gammaPow, gamma).(*mod.Int)
+	}
+	c := primitives.MultiScalarMultG1(ts.Curve, cs, ks)
+	return Verify(ts, c, proof, z, y)
+}
+
+func multiEvaluationChallenge(ts *TrustedSetup, tr *primitives.Transcript, cs []primitives.G1, z *mod.Int, ys []*mod.Int) *mod.Int {
+	for _, c := range cs {
+		tr.AppendG1("commitment", c)
+	}
+	tr.AppendScalar("z", z)
+	tr.AppendScalars("y", ys)
+	return tr.ChallengeScalar("gamma", ts.Curve.ScalarField())
+}
+
+//
+// Many polynomials opened at different sets of points, SHPLONK
+// (Boneh, Drake, Fisch, Gabizon, https://eprint.iacr.org/2020/081)
+//
+
+// ShplonkProof opens polynomials fᵢ at point sets Sᵢ with two 𝔾₁ elements
+type ShplonkProof struct {
+	W      primitives.G1 // [Σ γⁱ(fᵢ - rᵢ)/Z_Sᵢ]₁
+	WPrime primitives.G1 // [L(x)/(x - z)]₁
+}
+
+// ShplonkOpen opens each polynomial ps[i], committed in cs[i], at the points
+// points[i]. It returns the proof and the evaluations ps[i](points[i][j]).
+func ShplonkOpen(ts *TrustedSetup, ps []*primitives.Polynomial, cs []primitives.G1, points [][]*mod.Int, tr *primitives.Transcript) (*ShplonkProof, [][]*mod.Int, error) {
+	if len(ps) != len(cs) || len(ps) != len(points) {
+		return nil, nil, fmt.Errorf("len(ps), len(cs) and len(points) differ: %d, %d, %d", len(ps), len(cs), len(points))
+	}
+	f := ts.Curve.ScalarField()
+	evals := make([][]*mod.Int, len(ps))
+	rs := make([]*primitives.Polynomial, len(ps))
+	trees := make([]*primitives.SubproductTree, len(ps))
+	for i := range ps {
+		tree, err := primitives.NewSubproductTree(points[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		trees[i] = tree
+		evals[i] = tree.Evaluate(ps[i])
+		// rᵢ(x) interpolates fᵢ over Sᵢ
+		if rs[i], err = tree.Interpolate(evals[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+	gamma := shplonkGamma(ts, tr, cs, points, evals)
+
+	// W(x) = Σ γⁱ(fᵢ(x) - rᵢ(x)) / Z_Sᵢ(x)
+	w := new(primitives.Polynomial).InitFromZerosArrayOver(f, 1)
+	gammaPow := f.NewElement(1)
+	for i := range ps {
+		q, rem := new(primitives.Polynomial).Div(new(primitives.Polynomial).Sub(ps[i], rs[i]), trees[i].Root())
+		if !rem.IsZero() {
+			return nil, nil, fmt.Errorf("polynomial %d: remainder should be 0, instead is %s", i, rem.ToString())
+		}
+		w = new(primitives.Polynomial).Add(w, scale(q, gammaPow))
+		gammaPow = new(mod.Int).Mul(gammaPow, gamma).(*mod.Int)
+	}
+	wG1 := evaluateG1(ts, w.Coefficient)
+	tr.AppendG1("W", wG1)
+	z := tr.ChallengeScalar("z", f)
+
+	// L(x) = Σ γⁱ Z_{T\Sᵢ}(z) (fᵢ(x) - rᵢ(z)) - Z_T(z) W(x), which vanishes at z
+	zT, zTminusS := shplonkVanishing(f, points, z)
+	l := scale(w, new(mod.Int).Neg(zT).(*mod.Int))
+	gammaPow = f.NewElement(1)
+	for i := range ps {
+		fi := new(primitives.Polynomial).Sub(ps[i], new(primitives.Polynomial).Init([]*mod.Int{rs[i].Eval(z)}))
+		l = new(primitives.Polynomial).Add(l, scale(fi, new(mod.Int).Mul(gammaPow, zTminusS[i]).(*mod.Int)))
+		gammaPow = new(mod.Int).Mul(gammaPow, gamma).(*mod.Int)
+	}
+	q, rem := new(primitives.Polynomial).DivByLinear(l, z)
+	if rem.Nonzero() {
+		return nil, nil, fmt.Errorf("remainder should be 0, instead is %s", rem.String())
+	}
+	return &ShplonkProof{wG1, evaluateG1(ts, q.Coefficient)}, evals, nil
+}
+
+// ShplonkVerify verifies that the polynomials committed in cs evaluate to
+// evals[i][j] at points[i][j]. With
+//
+//	F = Σ γⁱ Z_{T\Sᵢ}(z) (cᵢ - [rᵢ(z)]₁) - Z_T(z) W
+//
+// it checks e(F + z·W', H) == e(W', [t]₂).
+func ShplonkVerify(ts *TrustedSetup, cs []primitives.G1, points, evals [][]*mod.Int, proof *ShplonkProof, tr *primitives.Transcript) bool {
+	if len(cs) != len(points) || len(cs) != len(evals) || len(cs) == 0 {
+		return false
+	}
+	f := ts.Curve.ScalarField()
+	gamma := shplonkGamma(ts, tr, cs, points, evals)
+	tr.AppendG1("W", proof.W)
+	z := tr.ChallengeScalar("z", f)
+
+	zT, zTminusS := shplonkVanishing(f, points, z)
+	ps := []primitives.G1{proof.W}
+	ks := []*big.Int{&new(mod.Int).Neg(zT).(*mod.Int).V}
+	r := f.NewElement(0) // Σ γⁱ Z_{T\Sᵢ}(z) rᵢ(z)
+	gammaPow := f.NewElement(1)
+	for i := range cs {
+		ri, err := new(primitives.Polynomial).LagrangeInterpolation(points[i], evals[i])
+		if err != nil {
+			return false
+		}
+		k := new(mod.Int).Mul(gammaPow, zTminusS[i]).(*mod.Int)
+		ps = append(ps, cs[i])
+		ks = append(ks, &k.V)
+		r = new(mod.Int).Add(r, new(mod.Int).Mul(k, ri.Eval(z))).(*mod.Int)
+		gammaPow = new(mod.Int).Mul(gammaPow, gamma).(*mod.Int)
+	}
+	ps = append(ps, ts.Curve.G1Generator(), proof.WPrime)
+	ks = append(ks, &new(mod.Int).Neg(r).(*mod.Int).V, &z.V)
+	// F + z·W'
+	fz := primitives.MultiScalarMultG1(ts.Curve, ps, ks)
+
+	return primitives.PairingCheck(
+		[]primitives.G1{fz, proof.WPrime.Neg()},
+		[]primitives.G2{ts.Curve.G2Generator(), ts.Tau2[1]})
+}
+
+func shplonkGamma(ts *TrustedSetup, tr *primitives.Transcript, cs []primitives.G1, points, evals [][]*mod.Int) *mod.Int {
+	for i := range cs {
+		tr.AppendG1("commitment", cs[i])
+		tr.AppendScalars("points", points[i])
+		tr.AppendScalars("evaluations", evals[i])
+	}
+	return tr.ChallengeScalar("gamma", ts.Curve.ScalarField())
+}
+
+// shplonkVanishing returns Z_T(z) and Z_{T\Sᵢ}(z) for every set Sᵢ, where T is
+// the union of the sets
+func shplonkVanishing(f primitives.Field, points [][]*mod.Int, z *mod.Int) (*mod.Int, []*mod.Int) {
+	var t []*mod.Int
+	seen := map[string]bool{}
+	for _, s := range points {
+		for _, x := range s {
+			if !seen[x.String()] {
+				seen[x.String()] = true
+				t = append(t, x)
+			}
+		}
+	}
+	zT := f.NewElement(1)
+	for _, x := range t {
+		zT = new(mod.Int).Mul(zT, new(mod.Int).Sub(z, x)).(*mod.Int)
+	}
+	zTminusS := make([]*mod.Int, len(points))
+	for i, s := range points {
+		in := map[string]bool{}
+		for _, x := range s {
+			in[x.String()] = true
+		}
+		zTminusS[i] = f.NewElement(1)
+		for _, x := range t {
+			if !in[x.String()] {
+				zTminusS[i] = new(mod.Int).Mul(zTminusS[i], new(mod.Int).Sub(z, x)).(*mod.Int)
+			}
+		}
+	}
+	return zT, zTminusS
+}
+
+// scale returns c·p without modifying p
+func scale(p *primitives.Polynomial, c *mod.Int) *primitives.Polynomial {
+	return new(primitives.Polynomial).MulByConstant(p.InitFromCopy(), c)
+}
@@ -0,0 +1,69 @@
+package Polynomial_commitment
+
+import (
+	"commitment/primitives"
+	"github.com/drand/kyber/group/mod"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func randPolynomials(ts *TrustedSetup, n, degree int) []*primitives.Polynomial {
+	f := ts.Curve.ScalarField()
+	ps := make([]*primitives.Polynomial, n)
+	for i := range ps {
+		coeffs, _ := f.RandVector(degree)
+		ps[i] = new(primitives.Polynomial).Init(coeffs)
+	}
+	return ps
+}
+
+func TestMultiEvaluationProof(t *testing.T) {
+	ts, err := NewTrustedSetup(10)
+	assert.Nil(t, err)
+	ps := randPolynomials(ts, 4, 10)
+	cs := make([]primitives.G1, len(ps))
+	ys := make([]*mod.Int, len(ps))
+	z := mod.NewInt64(42, primitives.Q)
+	for i := range ps {
+		cs[i] = Commit(ts, ps[i])
+		ys[i] = ps[i].Eval(z)
+	}
+
+	proof, err := MultiEvaluationProof(ts, ps, cs, z, ys, primitives.NewTranscript("test"))
+	assert.Nil(t, err)
+	assert.True(t, VerifyMultiEvaluation(ts, cs, proof, z, ys, primitives.NewTranscript("test")))
+
+	// the challenge depends on the transcript
+	assert.False(t, VerifyMultiEvaluation(ts, cs, proof, z, ys, primitives.NewTranscript("other")))
+	ys[2] = ys[1]
+	assert.False(t, VerifyMultiEvaluation(ts, cs, proof, z, ys, primitives.NewTranscript("test")))
+}
+
+func TestShplonk(t *testing.T) {
+	ts, err := NewTrustedSetup(12)
+	assert.Nil(t, err)
+	ps := randPolynomials(ts, 3, 12)
+	cs := make([]primitives.G1, len(ps))
+	for i := range ps {
+		cs[i] = Commit(ts, ps[i])
+	}
+	x := func(v int64) *mod.Int { return mod.NewInt64(v, primitives.Q) }
+	points := [][]*mod.Int{
+		{x(1), x(2), x(3)},
+		{x(2)},
+		{x(5), x(7)},
+	}
+
+	proof, evals, err := ShplonkOpen(ts, ps, cs, points, primitives.NewTranscript("test"))
+	assert.Nil(t, err)
+	assert.True(t, evals[2][1].Equal(ps[2].Eval(x(7))))
+	assert.True(t, ShplonkVerify(ts, cs, points, evals, proof, primitives.NewTranscript("test")))
+
+	// wrong evaluation
+	evals[0][2] = evals[1][0]
+	assert.False(t, ShplonkVerify(ts, cs, points, evals, proof, primitives.NewTranscript("test")))
+	evals[0][2] = ps[0].Eval(x(3))
+	// wrong commitment
+	cs[1], cs[2] = cs[2], cs[1]
+	assert.False(t, ShplonkVerify(ts, cs, points, evals, proof, primitives.NewTranscript("test")))
+}
@@ -0,0 +1,140 @@
+package Polynomial_commitment
+
+import (
+	"commitment/primitives"
+	"encoding/binary"
+	"fmt"
+	"github.com/drand/kyber/group/mod"
+	"io"
+)
+
+// WriteTo serializes the trusted setup: the number of 𝔾₁ and 𝔾₂ points as
+// big endian uint32 followed by the marshalled points
+func (ts *TrustedSetup) WriteTo(w io.Writer) (int64, error) {
+	var n int64
+	var header [8]byte
+	binary.BigEndian.PutUint32(header[:4], uint32(len(ts.Tau1)))
+	binary.BigEndian.PutUint32(header[4:], uint32(len(ts.Tau2)))
+	k, err := w.Write(header[:])
+	n += int64(k)
+	if err != nil {
+		return n, err
+	}
+	for _, p := range ts.Tau1 {
+		k, err = w.Write(p.Marshal())
+		n += int64(k)
+		if err != nil {
+			return n, err
+		}
+	}
+	for _, p := range ts.Tau2 {
+		k, err = w.Write(p.Marshal())
+		n += int64(k)
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// MaxTrustedSetupPoints bounds the number of points of each group read by
+// ReadTrustedSetup, 2²⁸ like the largest powers of tau ceremonies
+const MaxTrustedSetupPoints = 1 << 28
+
+// ReadTrustedSetup loads a trusted setup over the given curve written by
+// WriteTo, e.g. the output of a powers of tau ceremony. The points are
+// checked to be on the curve and to be the powers of the same τ. They are
+// read one by one, so a short input fails before the sizes of its header
+// are allocated.
+func ReadTrustedSetup(r io.Reader, curve primitives.Curve) (*TrustedSetup, error) {
+	var header [8]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+	n1 := int(binary.BigEndian.Uint32(header[:4]))
+	n2 := int(binary.BigEndian.Uint32(header[4:]))
+	if n1 < 2 || n2 < 2 || n1 > MaxTrustedSetupPoints || n2 > MaxTrustedSetupPoints {
+		return nil, fmt.Errorf("the trusted setup needs between 2 and %d points of each group, got %d and %d", MaxTrustedSetupPoints, n1, n2)
+	}
+	g1Size := len(curve.G1Generator().Marshal())
+	g2Size := len(curve.G2Generator().Marshal())
+
+	var tau1 []primitives.G1
+	buf := make([]byte, g1Size)
+	for i := 0; i < n1; i++ {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		p, err := curve.UnmarshalG1(buf)
+		if err != nil {
+			return nil, fmt.Errorf("point %d of G1: %v", i, err)
+		}
+		tau1 = append(tau1, p)
+	}
+	var tau2 []primitives.G2
+	buf = make([]byte, g2Size)
+	for i := 0; i < n2; i++ {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		p, err := curve.UnmarshalG2(buf)
+		if err != nil {
+			return nil, fmt.Errorf("point %d of G2: %v", i, err)
+		}
+		tau2 = append(tau2, p)
+	}
+	ts := &TrustedSetup{curve, tau1, tau2}
+	if !ts.Verify() {
+		return nil, fmt.Errorf("the points are not the powers of the same tau")
+	}
+	return ts, nil
+}
+
+// Verify checks that the points of the setup are the powers of the same τ,
+// starting at the generators: for random rᵢ and sⱼ
+//
+//	e(Σ rᵢ[τⁱ⁺¹]₁, H) == e(Σ rᵢ[τⁱ]₁, [τ]₂)
+//	e(G, Σ sⱼ[τʲ⁺¹]₂) == e([τ]₁, Σ sⱼ[τʲ]₂)
+func (ts *TrustedSetup) Verify() bool {
+	if len(ts.Tau1) < 2 || len(ts.Tau2) < 2 {
+		return false
+	}
+	g, h := ts.Curve.G1Generator(), ts.Curve.G2Generator()
+	if !ts.Tau1[0].Equal(g) || !ts.Tau2[0].Equal(h) {
+		return false
+	}
+	f := ts.Curve.ScalarField()
+	rs := make([]*mod.Int, len(ts.Tau1)-1)
+	for i := range rs {
+		r, err := f.Rand()
+		if err != nil {
+			return false
+		}
+		rs[i] = r
+	}
+	ss := make([]*mod.Int, len(ts.Tau2)-1)
+	for j := range ss {
+		s, err := f.Rand()
+		if err != nil {
+			return false
+		}
+		ss[j] = s
+	}
+	a := primitives.MultiScalarMultG1(ts.Curve, ts.Tau1[1:], primitives.Scalars(rs))
+	b := primitives.MultiScalarMultG1(ts.Curve, ts.Tau1[:len(ts.Tau1)-1], primitives.Scalars(rs))
+	c := primitives.MultiScalarMultG2(ts.Curve, ts.Tau2[1:], primitives.Scalars(ss))
+	d := primitives.MultiScalarMultG2(ts.Curve, ts.Tau2[:len(ts.Tau2)-1], primitives.Scalars(ss))
+	return primitives.PairingCheck([]primitives.G1{a, b.Neg()}, []primitives.G2{h, ts.Tau2[1]}) &&
+		primitives.PairingCheck([]primitives.G1{g, ts.Tau1[1].Neg()}, []primitives.G2{c, d})
+}
+
+// LagrangeBasis returns the setup in Lagrange form over the domain of the
+// n-th roots of unity: [Lᵢ(t)]₁ for Lᵢ the Lagrange polynomial of ωⁱ. A
+// polynomial given by its evaluations vᵢ = p(ωⁱ) is committed as Σ vᵢ[Lᵢ(t)]₁.
+func (ts *TrustedSetup) LagrangeBasis(n int) ([]primitives.G1, error) {
+	if n > len(ts.Tau1) {
+		return nil, fmt.Errorf("the trusted setup has %d points, %d are needed", len(ts.Tau1), n)
+	}
+	// Lᵢ(x) = 1/n Σⱼ ω⁻ⁱʲxʲ
+	return primitives.InverseNTTG1(ts.Tau1[:n])
+}
@@ -0,0 +1,70 @@
+package Polynomial_commitment
+
+import (
+	"bytes"
+	"commitment/primitives"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTrustedSetup_WriteTo(t *testing.T) {
+	for _, curve := range []primitives.Curve{primitives.CurveBN254, primitives.CurveBLS12381} {
+		ts, err := NewTrustedSetupOver(curve, 3)
+		assert.Nil(t, err)
+		var buf bytes.Buffer
+		_, err = ts.WriteTo(&buf)
+		assert.Nil(t, err)
+
+		loaded, err := ReadTrustedSetup(bytes.NewReader(buf.Bytes()), curve)
+		assert.Nil(t, err)
+		for i := range ts.Tau1 {
+			assert.True(t, ts.Tau1[i].Equal(loaded.Tau1[i]))
+			assert.True(t, ts.Tau2[i].Equal(loaded.Tau2[i]))
+		}
+
+		_, err = ReadTrustedSetup(bytes.NewReader(buf.Bytes()[:buf.Len()-1]), curve)
+		assert.NotNil(t, err)
+		assert.True(t, ts.Verify())
+
+		// the powers must be of the same τ
+		other, err := NewTrustedSetupOver(curve, 3)
+		assert.Nil(t, err)
+		mixed := &TrustedSetup{curve, []primitives.G1{ts.Tau1[0], ts.Tau1[1], other.Tau1[2]}, ts.Tau2}
+		assert.False(t, mixed.Verify())
+		mixed = &TrustedSetup{curve, ts.Tau1, []primitives.G2{ts.Tau2[0], ts.Tau2[1], other.Tau2[2]}}
+		assert.False(t, mixed.Verify())
+		buf.Reset()
+		_, err = mixed.WriteTo(&buf)
+		assert.Nil(t, err)
+		_, err = ReadTrustedSetup(bytes.NewReader(buf.Bytes()), curve)
+		assert.NotNil(t, err)
+	}
+}
+
+func TestReadTrustedSetup_Header(t *testing.T) {
+	// a header announcing 2³² - 1 points and no point fails without
+	// allocating them
+	_, err := ReadTrustedSetup(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff, 0, 0, 0, 2}), primitives.CurveBN254)
+	assert.NotNil(t, err)
+	_, err = ReadTrustedSetup(bytes.NewReader([]byte{0x01, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0}), primitives.CurveBN254)
+	assert.NotNil(t, err)
+}
+
+func TestTrustedSetup_LagrangeBasis(t *testing.T) {
+	ts, err := NewTrustedSetup(8)
+	assert.Nil(t, err)
+	p := randPolynomials(ts, 1, 8)[0]
+	evals, err := primitives.NTT(p.Coefficient, 8)
+	assert.Nil(t, err)
+
+	lagrange, err := ts.LagrangeBasis(8)
+	assert.Nil(t, err)
+	c := primitives.G1Zero(ts.Curve)
+	for i := range evals {
+		c = c.Add(lagrange[i].ScalarMult(&evals[i].V))
+	}
+	assert.True(t, c.Equal(Commit(ts, p)))
+
+	_, err = ts.LagrangeBasis(16)
+	assert.NotNil(t, err)
+}
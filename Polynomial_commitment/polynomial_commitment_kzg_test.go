@@ -132,3 +132,67 @@ func TestBatchProof(t *testing.T) {
 	v = VerifyBatchProof(ts, c, proof, zs, ys)
 	assert.False(t, v)
 }
+
+func TestVerifyMalformed(t *testing.T) {
+	ts, err := NewTrustedSetup(4)
+	assert.Nil(t, err)
+	p := new(primitives.Polynomial).Init([]*mod.Int{primitives.BN254.NewElement(5), primitives.BN254.NewElement(1)})
+	c := Commit(ts, p)
+	z := primitives.BN254.NewElement(3)
+	assert.False(t, Verify(ts, c, nil, z, p.Eval(z)))
+	assert.False(t, Verify(ts, nil, c, z, p.Eval(z)))
+
+	// more points than the powers of 𝔾₂ do not panic
+	zs, ys := make([]*mod.Int, 5), make([]*mod.Int, 5)
+	for i := range zs {
+		zs[i] = primitives.BN254.NewElement(int64(i + 1))
+		ys[i] = p.Eval(zs[i])
+	}
+	assert.False(t, VerifyBatchProof(ts, c, c, zs, ys))
+	assert.False(t, VerifyBatchProof(ts, c, c, zs[:2], ys[:3]))
+	assert.False(t, VerifyBatchProof(ts, c, nil, zs[:2], ys[:2]))
+	assert.False(t, VerifyBatchProof(ts, nil, c, zs[:2], ys[:2]))
+}
+
+func TestSimpleFlowBLS12381(t *testing.T) {
+	f := primitives.BLS12381
+	// p(x) = x^3 + x + 5
+	p := new(primitives.Polynomial).Init([]*mod.Int{
+		f.NewElement(5),
+		f.NewElement(1), // x^1
+		f.NewElement(0), // x^2
+		f.NewElement(1), // x^3
+	})
+
+	ts, err := NewTrustedSetupOver(primitives.CurveBLS12381, p.Degree)
+	assert.Nil(t, err)
+	c := Commit(ts, p)
+
+	// p(3)=35
+	z := f.NewElement(3)
+	y := f.NewElement(35)
+	proof, err := EvaluationProof(ts, p, z, y)
+	assert.Nil(t, err)
+	assert.True(t, Verify(ts, c, proof, z, y))
+	assert.False(t, Verify(ts, c, proof, f.NewElement(4), y))
+}
+
+func TestBatchProofBLS12381(t *testing.T) {
+	f := primitives.BLS12381
+	// p(x) = 10x^4+x^3 + x + 5
+	p := new(primitives.Polynomial).Init([]*mod.Int{
+		f.NewElement(5), f.NewElement(1), f.NewElement(0), f.NewElement(1), f.NewElement(10),
+	})
+	ts, err := NewTrustedSetupOver(primitives.CurveBLS12381, p.Degree)
+	assert.Nil(t, err)
+	c := Commit(ts, p)
+
+	zs := []*mod.Int{f.NewElement(3), f.NewElement(10), f.NewElement(256)}
+	ys := p.BatchEval(zs)
+	proof, err := EvaluationBatchProof(ts, p, zs, ys)
+	assert.Nil(t, err)
+	assert.True(t, VerifyBatchProof(ts, c, proof, zs, ys))
+
+	ys[1] = f.NewElement(1)
+	assert.False(t, VerifyBatchProof(ts, c, proof, zs, ys))
+}
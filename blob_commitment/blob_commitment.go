@@ -0,0 +1,213 @@
+// Package blob_commitment implements EIP-4844 style blob commitments: a blob
+// is a polynomial given by its evaluations over the roots of unity domain in
+// bit-reversed order, committed with KZG against a loaded trusted setup.
+// https://eips.ethereum.org/EIPS/eip-4844
+package blob_commitment
+
+import (
+	"commitment/Polynomial_commitment"
+	"commitment/primitives"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"github.com/drand/kyber/group/mod"
+	"math/big"
+	"math/bits"
+)
+
+const (
+	// FieldElementsPerBlob is the number of evaluations of a blob
+	FieldElementsPerBlob = 4096
+	// BytesPerFieldElement is the size of a serialized field element
+	BytesPerFieldElement = 32
+	// VersionedHashVersionKZG is the version byte of the versioned hashes
+	VersionedHashVersionKZG = 0x01
+	// fiatShamirProtocolDomain separates the blob challenges from other hashes
+	fiatShamirProtocolDomain = "FSBLOBVERIFY_V1_"
+)
+
+// Blob holds the evaluations of a polynomial over the bit-reversed domain
+type Blob []*mod.Int
+
+// Context holds the domain and the setup in Lagrange form for blobs of a
+// given size, FieldElementsPerBlob for Ethereum blobs
+type Context struct {
+	ts       *Polynomial_commitment.TrustedSetup
+	n        int
+	domain   []*mod.Int      // ω^bitrev(i)
+	lagrange []primitives.G1 // [L_bitrev(i)(t)]₁
+	field    primitives.Field
+}
+
+// NewContext prepares the commitments of blobs of n field elements
+func NewContext(ts *Polynomial_commitment.TrustedSetup, n int) (*Context, error) {
+	f := ts.Curve.ScalarField()
+	omega, err := f.RootOfUnity(n)
+	if err != nil {
+		return nil, err
+	}
+	lagrange, err := ts.LagrangeBasis(n)
+	if err != nil {
+		return nil, err
+	}
+	ctx := &Context{ts, n, make([]*mod.Int, n), make([]primitives.G1, n), f}
+	for i := 0; i < n; i++ {
+		j := bitReverse(i, n)
+		ctx.domain[i] = new(mod.Int).Exp(omega, big.NewInt(int64(j))).(*mod.Int)
+		ctx.lagrange[i] = lagrange[j]
+	}
+	return ctx, nil
+}
+
+// BlobFromBytes parses a blob of n big endian field elements of 32 bytes,
+// rejecting non canonical elements
+func (ctx *Context) BlobFromBytes(b []byte) (Blob, error) {
+	if len(b) != ctx.n*BytesPerFieldElement {
+		return nil, fmt.Errorf("a blob is %d bytes, got %d", ctx.n*BytesPerFieldElement, len(b))
+	}
+	blob := make(Blob, ctx.n)
+	for i := range blob {
+		v := new(big.Int).SetBytes(b[i*BytesPerFieldElement : (i+1)*BytesPerFieldElement])
+		if v.Cmp(ctx.field.Modulus()) >= 0 {
+			return nil, fmt.Errorf("field element %d is not canonical", i)
+		}
+		blob[i] = ctx.field.NewElementFromBig(v)
+	}
+	return blob, nil
+}
+
+// Bytes serializes the blob
+func (blob Blob) Bytes() []byte {
+	b := make([]byte, len(blob)*BytesPerFieldElement)
+	for i, v := range blob {
+		v.V.FillBytes(b[i*BytesPerFieldElement : (i+1)*BytesPerFieldElement])
+	}
+	return b
+}
+
+// BlobToCommitment returns the KZG commitment Σ blobᵢ·[Lᵢ(t)]₁
+func (ctx *Context) BlobToCommitment(blob Blob) (primitives.G1, error) {
+	if len(blob) != ctx.n {
+		return nil, fmt.Errorf("a blob has %d field elements, got %d", ctx.n, len(blob))
+	}
+	return primitives.MultiScalarMultG1(ctx.ts.Curve, ctx.lagrange, primitives.Scalars(blob)), nil
+}
+
+// ComputeProof returns the KZG proof of the evaluation of the blob at z,
+// and the evaluation y
+func (ctx *Context) ComputeProof(blob Blob, z *mod.Int) (primitives.G1, *mod.Int, error) {
+	if len(blob) != ctx.n {
+		return nil, nil, fmt.Errorf("a blob has %d field elements, got %d", ctx.n, len(blob))
+	}
+	y := ctx.evaluate(blob, z)
+
+	// qᵢ = (blobᵢ - y) / (ωᵢ - z)
+	q := make([]*mod.Int, ctx.n)
+	for i := range q {
+		d := new(mod.Int).Sub(ctx.domain[i], z).(*mod.Int)
+		if !d.Nonzero() {
+			q[i] = ctx.quotientWithinDomain(blob, i, y)
+			continue
+		}
+		q[i] = new(mod.Int).Div(new(mod.Int).Sub(blob[i], y), d).(*mod.Int)
+	}
+	return primitives.MultiScalarMultG1(ctx.ts.Curve, ctx.lagrange, primitives.Scalars(q)), y, nil
+}
+
+// VerifyProof verifies the KZG proof that the blob committed in c evaluates
+// to y at z
+func (ctx *Context) VerifyProof(c primitives.G1, z, y *mod.Int, proof primitives.G1) bool {
+	return Polynomial_commitment.Verify(ctx.ts, c, proof, z, y)
+}
+
+// ComputeBlobProof returns the proof of the blob at the Fiat-Shamir challenge
+// derived from the blob and its commitment
+func (ctx *Context) ComputeBlobProof(blob Blob, c primitives.G1) (primitives.G1, error) {
+	proof, _, err := ctx.ComputeProof(blob, ctx.challenge(blob, c))
+	return proof, err
+}
+
+// VerifyBlobProof verifies that the blob is the one committed in c
+func (ctx *Context) VerifyBlobProof(blob Blob, c, proof primitives.G1) bool {
+	if len(blob) != ctx.n {
+		return false
+	}
+	z := ctx.challenge(blob, c)
+	return ctx.VerifyProof(c, z, ctx.evaluate(blob, z), proof)
+}
+
+// VerifyBlobProofBatch verifies many blob proofs with one multi-pairing
+func (ctx *Context) VerifyBlobProofBatch(blobs []Blob, cs, proofs []primitives.G1) bool {
+	if len(blobs) != len(cs) || len(blobs) != len(proofs) {
+		return false
+	}
+	openings := make([]*Polynomial_commitment.Opening, len(blobs))
+	for i := range blobs {
+		if len(blobs[i]) != ctx.n {
+			return false
+		}
+		z := ctx.challenge(blobs[i], cs[i])
+		openings[i] = &Polynomial_commitment.Opening{C: cs[i], Proof: proofs[i], Z: z, Y: ctx.evaluate(blobs[i], z)}
+	}
+	return Polynomial_commitment.BatchVerify(ctx.ts, openings)
+}
+
+// VersionedHash returns the versioned hash of a commitment:
+// VERSIONED_HASH_VERSION_KZG ‖ sha256(commitment)[1:]
+func VersionedHash(c primitives.G1) [32]byte {
+	h := sha256.Sum256(c.Marshal())
+	h[0] = VersionedHashVersionKZG
+	return h
+}
+
+// challenge hashes the domain separator, the degree, the blob and its commitment
+func (ctx *Context) challenge(blob Blob, c primitives.G1) *mod.Int {
+	var degree [16]byte
+	binary.BigEndian.PutUint64(degree[8:], uint64(ctx.n))
+	h := sha256.New()
+	h.Write([]byte(fiatShamirProtocolDomain))
+	h.Write(degree[:])
+	h.Write(blob.Bytes())
+	h.Write(c.Marshal())
+	return ctx.field.NewElementFromBig(new(big.Int).SetBytes(h.Sum(nil)))
+}
+
+// evaluate evaluates the blob at z with the barycentric formula
+//
+//	p(z) = (zⁿ - 1)/n · Σ blobᵢ·ωᵢ/(z - ωᵢ)
+func (ctx *Context) evaluate(blob Blob, z *mod.Int) *mod.Int {
+	for i := range ctx.domain {
+		if ctx.domain[i].Equal(z) {
+			return blob[i]
+		}
+	}
+	r := ctx.field.NewElement(0)
+	for i := range blob {
+		num := new(mod.Int).Mul(blob[i], ctx.domain[i])
+		r = new(mod.Int).Add(r, new(mod.Int).Div(num, new(mod.Int).Sub(z, ctx.domain[i]))).(*mod.Int)
+	}
+	zn := new(mod.Int).Exp(z, big.NewInt(int64(ctx.n)))
+	zn = new(mod.Int).Sub(zn, ctx.field.NewElement(1))
+	r = new(mod.Int).Mul(r, zn).(*mod.Int)
+	return new(mod.Int).Div(r, ctx.field.NewElement(int64(ctx.n))).(*mod.Int)
+}
+
+// quotientWithinDomain returns q(ωₘ) when the proof is computed at z = ωₘ:
+// q(ωₘ) = Σ_{i≠m} (blobᵢ - y)·ωᵢ / (ωₘ(ωₘ - ωᵢ))
+func (ctx *Context) quotientWithinDomain(blob Blob, m int, y *mod.Int) *mod.Int {
+	z := ctx.domain[m]
+	r := ctx.field.NewElement(0)
+	for i := range blob {
+		if i == m {
+			continue
+		}
+		num := new(mod.Int).Mul(new(mod.Int).Sub(blob[i], y), ctx.domain[i])
+		den := new(mod.Int).Mul(z, new(mod.Int).Sub(z, ctx.domain[i]))
+		r = new(mod.Int).Add(r, new(mod.Int).Div(num, den)).(*mod.Int)
+	}
+	return r
+}
+
+func bitReverse(i, n int) int {
+	return int(bits.Reverse(uint(i)) >> (bits.UintSize - bits.TrailingZeros(uint(n))))
+}
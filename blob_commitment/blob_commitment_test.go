@@ -0,0 +1,109 @@
+package blob_commitment
+
+import (
+	"bytes"
+	"commitment/Polynomial_commitment"
+	"commitment/primitives"
+	"github.com/drand/kyber/group/mod"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const testBlobSize = 16
+
+// newTestContext writes a fresh trusted setup and loads it back, as done
+// with the output of a ceremony
+func newTestContext(t *testing.T, curve primitives.Curve) *Context {
+	ts, err := Polynomial_commitment.NewTrustedSetupOver(curve, testBlobSize)
+	assert.Nil(t, err)
+	var buf bytes.Buffer
+	_, err = ts.WriteTo(&buf)
+	assert.Nil(t, err)
+	loaded, err := Polynomial_commitment.ReadTrustedSetup(&buf, curve)
+	assert.Nil(t, err)
+	ctx, err := NewContext(loaded, testBlobSize)
+	assert.Nil(t, err)
+	return ctx
+}
+
+func randBlob(t *testing.T, ctx *Context) Blob {
+	blob := make(Blob, ctx.n)
+	for i := range blob {
+		v, err := ctx.field.Rand()
+		assert.Nil(t, err)
+		blob[i] = v
+	}
+	return blob
+}
+
+func TestBlobToCommitment(t *testing.T) {
+	ctx := newTestContext(t, primitives.CurveBN254)
+	blob := randBlob(t, ctx)
+	c, err := ctx.BlobToCommitment(blob)
+	assert.Nil(t, err)
+
+	// the commitment is the KZG commitment of the interpolated polynomial
+	evals := make([]*mod.Int, ctx.n)
+	for i := range blob {
+		evals[bitReverse(i, ctx.n)] = blob[i]
+	}
+	coeffs, err := primitives.InverseNTT(evals)
+	assert.Nil(t, err)
+	assert.True(t, c.Equal(Polynomial_commitment.Commit(ctx.ts, new(primitives.Polynomial).Init(coeffs))))
+
+	// z inside and outside the domain
+	for _, z := range []*mod.Int{ctx.domain[3], ctx.field.NewElement(12345)} {
+		proof, y, err := ctx.ComputeProof(blob, z)
+		assert.Nil(t, err)
+		assert.True(t, y.Equal(new(primitives.Polynomial).Init(coeffs).Eval(z)))
+		assert.True(t, ctx.VerifyProof(c, z, y, proof))
+	}
+
+	_, err = ctx.BlobToCommitment(blob[1:])
+	assert.NotNil(t, err)
+}
+
+func TestBlobProof(t *testing.T) {
+	for _, curve := range []primitives.Curve{primitives.CurveBN254, primitives.CurveBLS12381} {
+		ctx := newTestContext(t, curve)
+		blob := randBlob(t, ctx)
+		c, err := ctx.BlobToCommitment(blob)
+		assert.Nil(t, err)
+		proof, err := ctx.ComputeBlobProof(blob, c)
+		assert.Nil(t, err)
+		assert.True(t, ctx.VerifyBlobProof(blob, c, proof), curve.Name())
+
+		other := randBlob(t, ctx)
+		assert.False(t, ctx.VerifyBlobProof(other, c, proof), curve.Name())
+
+		oc, _ := ctx.BlobToCommitment(other)
+		op, _ := ctx.ComputeBlobProof(other, oc)
+		assert.True(t, ctx.VerifyBlobProofBatch([]Blob{blob, other}, []primitives.G1{c, oc}, []primitives.G1{proof, op}))
+		assert.False(t, ctx.VerifyBlobProofBatch([]Blob{blob, other}, []primitives.G1{c, oc}, []primitives.G1{op, proof}))
+	}
+}
+
+func TestBlobFromBytes(t *testing.T) {
+	ctx := newTestContext(t, primitives.CurveBN254)
+	blob := randBlob(t, ctx)
+	parsed, err := ctx.BlobFromBytes(blob.Bytes())
+	assert.Nil(t, err)
+	for i := range blob {
+		assert.True(t, blob[i].Equal(parsed[i]))
+	}
+
+	b := blob.Bytes()
+	primitives.Q.FillBytes(b[:BytesPerFieldElement])
+	_, err = ctx.BlobFromBytes(b)
+	assert.NotNil(t, err)
+	_, err = ctx.BlobFromBytes(b[1:])
+	assert.NotNil(t, err)
+}
+
+func TestVersionedHash(t *testing.T) {
+	ctx := newTestContext(t, primitives.CurveBN254)
+	c, _ := ctx.BlobToCommitment(randBlob(t, ctx))
+	h := VersionedHash(c)
+	assert.Equal(t, byte(VersionedHashVersionKZG), h[0])
+	assert.NotEqual(t, h, VersionedHash(c.Add(c)))
+}
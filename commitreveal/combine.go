@@ -0,0 +1,44 @@
+package commitreveal
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+// Combiner combines the revealed values, in the order of the parties
+type Combiner func(values [][]byte) ([]byte, error)
+
+// XOR combines values of the same length with ⊕, the result is uniform as
+// soon as one value is
+func XOR(values [][]byte) ([]byte, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("no value to combine")
+	}
+	r := make([]byte, len(values[0]))
+	for _, v := range values {
+		if len(v) != len(r) {
+			return nil, fmt.Errorf("the values have %d and %d bytes", len(r), len(v))
+		}
+		for i := range r {
+			r[i] ^= v[i]
+		}
+	}
+	return r, nil
+}
+
+// Hash combines values of any length with SHA-256 over their length
+// prefixed concatenation
+func Hash(values [][]byte) ([]byte, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("no value to combine")
+	}
+	h := sha256.New()
+	for _, v := range values {
+		var l [8]byte
+		binary.BigEndian.PutUint64(l[:], uint64(len(v)))
+		h.Write(l[:])
+		h.Write(v)
+	}
+	return h.Sum(nil), nil
+}
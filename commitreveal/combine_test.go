@@ -0,0 +1,27 @@
+package commitreveal
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestXOR(t *testing.T) {
+	r, err := XOR([][]byte{{0x0f, 0x01}, {0xf0, 0x01}, {0x00, 0x10}})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0xff, 0x10}, r)
+
+	_, err = XOR([][]byte{{1}, {1, 2}})
+	assert.NotNil(t, err)
+	_, err = XOR(nil)
+	assert.NotNil(t, err)
+}
+
+func TestHash(t *testing.T) {
+	a, err := Hash([][]byte{[]byte("ab"), []byte("c")})
+	assert.Nil(t, err)
+	b, err := Hash([][]byte{[]byte("a"), []byte("bc")})
+	assert.Nil(t, err)
+	// the values are length prefixed
+	assert.NotEqual(t, a, b)
+	assert.Len(t, a, 32)
+}
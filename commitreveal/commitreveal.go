@@ -0,0 +1,286 @@
+// Package commitreveal coordinates commit-reveal rounds between registered
+// parties, for randomness beacons or sealed bids: each party commits to a
+// value with a hash commitment before the commit deadline, then reveals it
+// before the reveal deadline, and the revealed values which open their
+// commitments are combined into the result of the round. Every action is
+// recorded in the log of the round.
+//
+// A party who sees the other reveals may withhold their own: the result
+// lists the missing reveals, and a caller who cannot tolerate them should
+// combine commit-reveal with timed commitments.
+package commitreveal
+
+import (
+	"commitment"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Phase is the phase of a round
+type Phase int
+
+const (
+	Committing Phase = iota
+	Revealing
+	Finalized
+)
+
+func (p Phase) String() string {
+	switch p {
+	case Committing:
+		return "committing"
+	case Revealing:
+		return "revealing"
+	case Finalized:
+		return "finalized"
+	}
+	return fmt.Sprintf("Phase(%d)", int(p))
+}
+
+// Event is an entry of the log of a round
+type Event struct {
+	Time   time.Time
+	Party  string
+	Kind   string // commit, reveal, mismatch, rejected or finalize
+	Detail string
+}
+
+// Result is the outcome of a round
+type Result struct {
+	Value      []byte   // the combination of the valid reveals, nil without any
+	Revealed   []string // the parties whose reveal opened their commitment
+	Mismatched []string // the parties whose reveal did not open their commitment
+	Missing    []string // the parties who committed and did not reveal
+	Absent     []string // the parties who did not commit
+}
+
+// Coordinator holds the rounds, with a clock which tests can replace
+type Coordinator struct {
+	mu     sync.Mutex
+	now    func() time.Time
+	rounds map[string]*Round
+}
+
+// NewCoordinator returns a coordinator reading the time with now, time.Now
+// if nil
+func NewCoordinator(now func() time.Time) *Coordinator {
+	if now == nil {
+		now = time.Now
+	}
+	return &Coordinator{now: now, rounds: make(map[string]*Round)}
+}
+
+// Round is a commit-reveal round between registered parties
+type Round struct {
+	ID                             string
+	Parties                        []string
+	CommitDeadline, RevealDeadline time.Time
+
+	mu          sync.Mutex
+	now         func() time.Time
+	hc          commitment.HashCommiter
+	combine     Combiner
+	phase       Phase
+	commitments map[string][]byte
+	values      map[string][]byte
+	mismatched  map[string]bool
+	result      *Result
+	log         []Event
+}
+
+// NewRound starts the round id between the parties, combining the reveals
+// with combine
+func (co *Coordinator) NewRound(id string, parties []string, commitDeadline, revealDeadline time.Time, combine Combiner) (*Round, error) {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+	if _, ok := co.rounds[id]; ok {
+		return nil, fmt.Errorf("round %q already exists", id)
+	}
+	if len(parties) == 0 {
+		return nil, fmt.Errorf("round %q has no party", id)
+	}
+	if combine == nil {
+		return nil, fmt.Errorf("round %q has no combiner", id)
+	}
+	seen := make(map[string]bool)
+	for _, p := range parties {
+		if seen[p] {
+			return nil, fmt.Errorf("party %q is registered twice", p)
+		}
+		seen[p] = true
+	}
+	if !revealDeadline.After(commitDeadline) {
+		return nil, fmt.Errorf("the reveal deadline must be after the commit deadline")
+	}
+	r := &Round{
+		ID:             id,
+		Parties:        append([]string{}, parties...),
+		CommitDeadline: commitDeadline,
+		RevealDeadline: revealDeadline,
+		now:            co.now,
+		hc:             commitment.NewHashCommiter(),
+		combine:        combine,
+		commitments:    make(map[string][]byte),
+		values:         make(map[string][]byte),
+		mismatched:     make(map[string]bool),
+	}
+	co.rounds[id] = r
+	return r, nil
+}
+
+// Round returns the round id
+func (co *Coordinator) Round(id string) (*Round, bool) {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+	r, ok := co.rounds[id]
+	return r, ok
+}
+
+// NewCommitment returns the commitment of a party to the value, with the
+// nonce to reveal along with it
+func NewCommitment(value []byte) ([]byte, []byte) {
+	hc := commitment.NewHashCommiter()
+	nonce := hc.Setup()
+	return hc.Commit(value, nonce), nonce
+}
+
+// Phase returns the phase of the round at the time of the clock: the round
+// reveals once all the parties committed or the commit deadline passed
+func (r *Round) Phase() Phase {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.currentPhase()
+}
+
+func (r *Round) currentPhase() Phase {
+	if r.phase == Committing && (len(r.commitments) == len(r.Parties) || !r.now().Before(r.CommitDeadline)) {
+		r.phase = Revealing
+	}
+	return r.phase
+}
+
+func (r *Round) registered(party string) bool {
+	for _, p := range r.Parties {
+		if p == party {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Round) record(party, kind, detail string) {
+	r.log = append(r.log, Event{Time: r.now(), Party: party, Kind: kind, Detail: detail})
+}
+
+// reject records and returns the error
+func (r *Round) reject(party string, format string, a ...interface{}) error {
+	err := fmt.Errorf(format, a...)
+	r.record(party, "rejected", err.Error())
+	return err
+}
+
+// Commit records the commitment of the party
+func (r *Round) Commit(party string, c []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.registered(party) {
+		return r.reject(party, "party %q is not registered in round %q", party, r.ID)
+	}
+	if phase := r.currentPhase(); phase != Committing {
+		return r.reject(party, "round %q is %s", r.ID, phase)
+	}
+	if _, ok := r.commitments[party]; ok {
+		return r.reject(party, "party %q already committed", party)
+	}
+	r.commitments[party] = append([]byte{}, c...)
+	r.record(party, "commit", fmt.Sprintf("%x", c))
+	return nil
+}
+
+// Reveal opens the commitment of the party to value with the nonce, of
+// commitment.NonceSize bytes. A reveal which does not open the commitment is
+// final.
+func (r *Round) Reveal(party string, value, nonce []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.commitments[party]
+	if !ok {
+		return r.reject(party, "party %q did not commit in round %q", party, r.ID)
+	}
+	if phase := r.currentPhase(); phase != Revealing {
+		return r.reject(party, "round %q is %s", r.ID, phase)
+	}
+	if !r.now().Before(r.RevealDeadline) {
+		return r.reject(party, "the reveal deadline of round %q passed", r.ID)
+	}
+	if _, ok := r.values[party]; ok || r.mismatched[party] {
+		return r.reject(party, "party %q already revealed", party)
+	}
+	if !r.hc.Verify(value, nonce, c) {
+		r.mismatched[party] = true
+		r.record(party, "mismatch", fmt.Sprintf("%x", value))
+		return fmt.Errorf("the reveal of party %q does not open its commitment", party)
+	}
+	r.values[party] = append([]byte{}, value...)
+	r.record(party, "reveal", fmt.Sprintf("%x", value))
+	return nil
+}
+
+// Finalize combines the valid reveals, once all the committed parties
+// revealed or the reveal deadline passed. The result is computed once.
+func (r *Round) Finalize() (*Result, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.result != nil {
+		return r.result, nil
+	}
+	if r.currentPhase() != Revealing {
+		return nil, fmt.Errorf("round %q is %s", r.ID, r.phase)
+	}
+	if len(r.values)+len(r.mismatched) < len(r.commitments) && r.now().Before(r.RevealDeadline) {
+		return nil, fmt.Errorf("round %q waits for %d reveals", r.ID, len(r.commitments)-len(r.values)-len(r.mismatched))
+	}
+	res := &Result{}
+	var values [][]byte
+	for _, p := range r.Parties {
+		switch {
+		case r.values[p] != nil:
+			res.Revealed = append(res.Revealed, p)
+			values = append(values, r.values[p])
+		case r.mismatched[p]:
+			res.Mismatched = append(res.Mismatched, p)
+		case r.commitments[p] != nil:
+			res.Missing = append(res.Missing, p)
+		default:
+			res.Absent = append(res.Absent, p)
+		}
+	}
+	// without any valid reveal the result only names the faulty parties
+	if len(values) > 0 {
+		v, err := r.combine(values)
+		if err != nil {
+			return nil, fmt.Errorf("round %q: %v", r.ID, err)
+		}
+		res.Value = v
+	}
+	r.phase, r.result = Finalized, res
+	r.record("", "finalize", fmt.Sprintf("%x revealed by %v", res.Value, res.Revealed))
+	return res, nil
+}
+
+// Log returns the events of the round, in order
+func (r *Round) Log() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Event{}, r.log...)
+}
+
+// Faulty returns the parties who did not commit, did not reveal or revealed
+// a wrong value, sorted, once the round is finalized
+func (r *Result) Faulty() []string {
+	f := append(append(append([]string{}, r.Mismatched...), r.Missing...), r.Absent...)
+	sort.Strings(f)
+	return f
+}
@@ -0,0 +1,159 @@
+package commitreveal
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type clock struct {
+	t time.Time
+}
+
+func (c *clock) now() time.Time {
+	return c.t
+}
+
+func newRound(t *testing.T, combine Combiner) (*clock, *Round) {
+	c := &clock{time.Unix(0, 0)}
+	co := NewCoordinator(c.now)
+	r, err := co.NewRound("beacon", []string{"alice", "bob", "carol"}, c.t.Add(time.Minute), c.t.Add(2*time.Minute), combine)
+	assert.Nil(t, err)
+	got, ok := co.Round("beacon")
+	assert.True(t, ok)
+	assert.Equal(t, r, got)
+	return c, r
+}
+
+func TestRound(t *testing.T) {
+	_, r := newRound(t, XOR)
+	values := map[string][]byte{"alice": {1, 2}, "bob": {4, 8}, "carol": {16, 32}}
+	nonces := make(map[string][]byte)
+	for _, p := range r.Parties {
+		c, nonce := NewCommitment(values[p])
+		nonces[p] = nonce
+		assert.Nil(t, r.Commit(p, c))
+	}
+	// everybody committed, no need to wait for the deadline
+	assert.Equal(t, Revealing, r.Phase())
+	_, err := r.Finalize()
+	assert.NotNil(t, err)
+	for _, p := range r.Parties {
+		assert.Nil(t, r.Reveal(p, values[p], nonces[p]))
+	}
+
+	res, err := r.Finalize()
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{21, 42}, res.Value)
+	assert.Equal(t, []string{"alice", "bob", "carol"}, res.Revealed)
+	assert.Empty(t, res.Faulty())
+	assert.Equal(t, Finalized, r.Phase())
+	assert.Len(t, r.Log(), 7)
+}
+
+func TestFaultyParties(t *testing.T) {
+	c, r := newRound(t, Hash)
+	ca, na := NewCommitment([]byte("a"))
+	cb, _ := NewCommitment([]byte("b"))
+	assert.Nil(t, r.Commit("alice", ca))
+	assert.Nil(t, r.Commit("bob", cb))
+	assert.NotNil(t, r.Commit("mallory", ca))
+	assert.NotNil(t, r.Commit("alice", cb))
+	// reveals wait for the commit deadline
+	assert.NotNil(t, r.Reveal("alice", []byte("a"), na))
+
+	c.t = c.t.Add(time.Minute)
+	assert.Equal(t, Revealing, r.Phase())
+	assert.NotNil(t, r.Commit("carol", ca))
+	assert.Nil(t, r.Reveal("alice", []byte("a"), na))
+	assert.NotNil(t, r.Reveal("alice", []byte("a"), na))
+	assert.NotNil(t, r.Reveal("carol", []byte("c"), na))
+	_, err := r.Finalize()
+	assert.NotNil(t, err)
+
+	c.t = c.t.Add(time.Minute)
+	assert.NotNil(t, r.Reveal("bob", []byte("b"), na))
+	res, err := r.Finalize()
+	assert.Nil(t, err)
+	expected, _ := Hash([][]byte{[]byte("a")})
+	assert.Equal(t, expected, res.Value)
+	assert.Equal(t, []string{"bob"}, res.Missing)
+	assert.Equal(t, []string{"carol"}, res.Absent)
+	assert.Equal(t, []string{"bob", "carol"}, res.Faulty())
+}
+
+func TestMismatch(t *testing.T) {
+	c, r := newRound(t, XOR)
+	ca, na := NewCommitment([]byte{1})
+	cb, nb := NewCommitment([]byte{2})
+	assert.Nil(t, r.Commit("alice", ca))
+	assert.Nil(t, r.Commit("bob", cb))
+	c.t = c.t.Add(time.Minute)
+	assert.NotNil(t, r.Reveal("alice", []byte{3}, na))
+	// the mismatch is final
+	assert.NotNil(t, r.Reveal("alice", []byte{1}, na))
+	assert.Nil(t, r.Reveal("bob", []byte{2}, nb))
+
+	res, err := r.Finalize()
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{2}, res.Value)
+	assert.Equal(t, []string{"alice"}, res.Mismatched)
+
+	var kinds []string
+	for _, e := range r.Log() {
+		kinds = append(kinds, e.Kind)
+	}
+	assert.Equal(t, []string{"commit", "commit", "mismatch", "rejected", "reveal", "finalize"}, kinds)
+}
+
+func TestNoReveal(t *testing.T) {
+	c, r := newRound(t, XOR)
+	ca, _ := NewCommitment([]byte{1})
+	cb, _ := NewCommitment([]byte{2})
+	assert.Nil(t, r.Commit("alice", ca))
+	assert.Nil(t, r.Commit("bob", cb))
+	c.t = c.t.Add(2 * time.Minute)
+
+	// nobody revealed, the round still finalizes and names the parties
+	res, err := r.Finalize()
+	assert.Nil(t, err)
+	assert.Nil(t, res.Value)
+	assert.Empty(t, res.Revealed)
+	assert.Equal(t, []string{"alice", "bob"}, res.Missing)
+	assert.Equal(t, []string{"carol"}, res.Absent)
+	assert.Equal(t, Finalized, r.Phase())
+	again, err := r.Finalize()
+	assert.Nil(t, err)
+	assert.Equal(t, res, again)
+}
+
+func TestEquivocation(t *testing.T) {
+	_, r := newRound(t, XOR)
+	ca, na := NewCommitment([]byte("value-one"))
+	assert.Nil(t, r.Commit("alice", ca))
+	cb, nb := NewCommitment([]byte("other"))
+	assert.Nil(t, r.Commit("bob", cb))
+	cc, _ := NewCommitment([]byte("carol"))
+	assert.Nil(t, r.Commit("carol", cc))
+
+	// alice cannot open her commitment to "value" by moving "-one" into
+	// the nonce
+	assert.NotNil(t, r.Reveal("alice", []byte("value"), append([]byte("-one"), na...)))
+	assert.NotNil(t, r.Reveal("alice", []byte("value-one"), na))
+	assert.Nil(t, r.Reveal("bob", []byte("other"), nb))
+}
+
+func TestNewRound(t *testing.T) {
+	co := NewCoordinator(nil)
+	now := time.Now()
+	_, err := co.NewRound("a", []string{"alice", "alice"}, now, now.Add(time.Second), XOR)
+	assert.NotNil(t, err)
+	_, err = co.NewRound("a", []string{"alice"}, now, now, XOR)
+	assert.NotNil(t, err)
+	_, err = co.NewRound("a", []string{"alice"}, now, now.Add(time.Second), nil)
+	assert.NotNil(t, err)
+	_, err = co.NewRound("a", []string{"alice"}, now, now.Add(time.Second), XOR)
+	assert.Nil(t, err)
+	_, err = co.NewRound("a", []string{"alice"}, now, now.Add(time.Second), XOR)
+	assert.NotNil(t, err)
+}
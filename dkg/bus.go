@@ -0,0 +1,50 @@
+package dkg
+
+import "sync"
+
+// Message is a message of the protocol, To is 0 for a broadcast
+type Message struct {
+	From, To int
+	Payload  interface{}
+}
+
+// Bus is an in memory network of authenticated private channels and of a
+// reliable broadcast channel, for tests and simulations
+type Bus struct {
+	mu         sync.Mutex
+	broadcasts []*Message
+	inboxes    map[int][]*Message
+}
+
+// NewBus returns an empty bus
+func NewBus() *Bus {
+	return &Bus{inboxes: make(map[int][]*Message)}
+}
+
+// Broadcast sends the payload to every party
+func (b *Bus) Broadcast(from int, payload interface{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.broadcasts = append(b.broadcasts, &Message{From: from, Payload: payload})
+}
+
+// Send sends the payload privately to the party to
+func (b *Bus) Send(from, to int, payload interface{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.inboxes[to] = append(b.inboxes[to], &Message{From: from, To: to, Payload: payload})
+}
+
+// Broadcasts returns all the broadcast messages, in order
+func (b *Bus) Broadcasts() []*Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]*Message{}, b.broadcasts...)
+}
+
+// Inbox returns the private messages received by the party to, in order
+func (b *Bus) Inbox(to int) []*Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]*Message{}, b.inboxes[to]...)
+}
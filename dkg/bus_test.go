@@ -0,0 +1,17 @@
+package dkg
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBus(t *testing.T) {
+	b := NewBus()
+	b.Broadcast(1, "a")
+	b.Send(1, 2, "b")
+	b.Send(3, 2, "c")
+
+	assert.Equal(t, []*Message{{From: 1, Payload: "a"}}, b.Broadcasts())
+	assert.Equal(t, []*Message{{From: 1, To: 2, Payload: "b"}, {From: 3, To: 2, Payload: "c"}}, b.Inbox(2))
+	assert.Empty(t, b.Inbox(1))
+}
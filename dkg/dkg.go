@@ -0,0 +1,313 @@
+// Package dkg implements the distributed key generation of Gennaro,
+// Jarecki, Krawczyk and Rabin (GJKR), with no trusted dealer: every party
+// deals a random secret with Pedersen VSS, the parties complain against the
+// dealers of invalid shares, the dealers who cannot justify themselves are
+// disqualified, and the group secret x = Σ zᵢ over the qualified dealers is
+// shared with threshold t, as the sum xⱼ of the shares received by each
+// party. The group key [x]G is extracted with Feldman commitments once the
+// qualified set is fixed, so that no party can bias it.
+// https://link.springer.com/article/10.1007/s00145-006-0347-3
+package dkg
+
+import (
+	"commitment/primitives"
+	"commitment/vss"
+	"fmt"
+	"github.com/drand/kyber/group/mod"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"math/big"
+)
+
+// Deal is the Pedersen commitment broadcast by a dealer
+type Deal struct {
+	Commitment *vss.PedersenCommitment
+}
+
+// PrivateShare is the share sent by a dealer to a party
+type PrivateShare struct {
+	Share, Blind *vss.Share
+}
+
+// Complaint accuses Dealer of sending an invalid share, or none
+type Complaint struct {
+	Dealer int
+}
+
+// Justification answers the complaint of Accuser by broadcasting its share
+type Justification struct {
+	Accuser      int
+	Share, Blind *vss.Share
+}
+
+// Extraction is the Feldman commitment broadcast by a qualified dealer
+type Extraction struct {
+	Commitment *vss.FeldmanCommitment
+}
+
+// ExtractionComplaint reveals a share of Dealer which is valid for its deal
+// and not for its extraction
+type ExtractionComplaint struct {
+	Dealer       int
+	Share, Blind *vss.Share
+}
+
+// Reconstruction reveals a share of Dealer, whose secret is reconstructed in
+// the clear because its extraction failed
+type Reconstruction struct {
+	Dealer       int
+	Share, Blind *vss.Share
+}
+
+// KeyShare is the output of the protocol for a party
+type KeyShare struct {
+	Qual     []int     // the qualified dealers
+	GroupKey *bn256.G1 // [x]G
+	Share    *vss.Share
+}
+
+// Party is a party of the protocol, with an index in 1..n. The phases are
+// run in order by all the parties, each phase reading the messages of the
+// previous ones: Deal, Complain, Justify, Qualify, Extract,
+// ComplainExtraction, Reconstruct and Result.
+type Party struct {
+	Index, t, n int
+	bus         *Bus
+	dealer      *vss.Dealer
+
+	deals          map[int]*vss.PedersenCommitment
+	shares, blinds map[int]*vss.Share // the shares received from each dealer
+	qual           []int
+	extractions    map[int]*vss.FeldmanCommitment
+	reconstructed  map[int]bool // the dealers to reconstruct
+}
+
+// NewParty returns the party index of n with threshold t, on the bus
+func NewParty(bus *Bus, index, t, n int) (*Party, error) {
+	if index < 1 || index > n {
+		return nil, fmt.Errorf("the index must be in 1..%d, got %d", n, index)
+	}
+	secret, err := primitives.BN254.Rand()
+	if err != nil {
+		return nil, err
+	}
+	dealer, err := vss.NewDealer(secret, t, n)
+	if err != nil {
+		return nil, err
+	}
+	return &Party{
+		Index:  index,
+		t:      t,
+		n:      n,
+		bus:    bus,
+		dealer: dealer,
+		shares: make(map[int]*vss.Share),
+		blinds: make(map[int]*vss.Share),
+	}, nil
+}
+
+// Deal broadcasts the commitment to the polynomial of the party and sends
+// their shares to the parties
+func (p *Party) Deal() {
+	p.bus.Broadcast(p.Index, &Deal{p.dealer.PedersenCommitment()})
+	shares, blinds := p.dealer.Shares(), p.dealer.BlindShares()
+	for j := 1; j <= p.n; j++ {
+		p.bus.Send(p.Index, j, &PrivateShare{shares[j-1], blinds[j-1]})
+	}
+}
+
+// Complain verifies the shares received and complains against the dealers
+// of invalid or missing shares
+func (p *Party) Complain() {
+	p.deals = make(map[int]*vss.PedersenCommitment)
+	for _, m := range p.bus.Broadcasts() {
+		if d, ok := m.Payload.(*Deal); ok && d != nil && d.Commitment != nil && p.deals[m.From] == nil && d.Commitment.Threshold() == p.t {
+			p.deals[m.From] = d.Commitment
+		}
+	}
+	// a malformed share is handled as a missing one
+	for _, m := range p.bus.Inbox(p.Index) {
+		if s, ok := m.Payload.(*PrivateShare); ok && s != nil && wellFormed(s.Share, s.Blind) && p.shares[m.From] == nil {
+			p.shares[m.From], p.blinds[m.From] = s.Share, s.Blind
+		}
+	}
+	for i := 1; i <= p.n; i++ {
+		c, ok := p.deals[i]
+		if !ok {
+			// disqualified without a complaint
+			continue
+		}
+		if p.shares[i] == nil || p.shares[i].Index != p.Index || !vss.PedersenVerify(c, p.shares[i], p.blinds[i]) {
+			delete(p.shares, i)
+			delete(p.blinds, i)
+			p.bus.Broadcast(p.Index, &Complaint{i})
+		}
+	}
+}
+
+// Justify answers the complaints against the party
+func (p *Party) Justify() {
+	shares, blinds := p.dealer.Shares(), p.dealer.BlindShares()
+	for _, m := range p.bus.Broadcasts() {
+		if c, ok := m.Payload.(*Complaint); ok && c != nil && c.Dealer == p.Index && m.From >= 1 && m.From <= p.n {
+			p.bus.Broadcast(p.Index, &Justification{m.From, shares[m.From-1], blinds[m.From-1]})
+		}
+	}
+}
+
+// Qualify returns the qualified dealers, the same for all the honest
+// parties: the dealers who broadcast a deal, received at most t complaints
+// and justified all of them. The party adopts the justified shares.
+func (p *Party) Qualify() []int {
+	complaints := make(map[int]map[int]bool)
+	justified := make(map[int]map[int]bool)
+	for _, m := range p.bus.Broadcasts() {
+		switch v := m.Payload.(type) {
+		case *Complaint:
+			if v == nil {
+				continue
+			}
+			if complaints[v.Dealer] == nil {
+				complaints[v.Dealer] = make(map[int]bool)
+			}
+			complaints[v.Dealer][m.From] = true
+		case *Justification:
+			c, ok := p.deals[m.From]
+			if !ok || v == nil || !wellFormed(v.Share, v.Blind) || v.Share.Index != v.Accuser || !vss.PedersenVerify(c, v.Share, v.Blind) {
+				continue
+			}
+			if justified[m.From] == nil {
+				justified[m.From] = make(map[int]bool)
+			}
+			justified[m.From][v.Accuser] = true
+			if v.Accuser == p.Index && p.shares[m.From] == nil {
+				p.shares[m.From], p.blinds[m.From] = v.Share, v.Blind
+			}
+		}
+	}
+	p.qual = nil
+	for i := 1; i <= p.n; i++ {
+		if _, ok := p.deals[i]; !ok || len(complaints[i]) > p.t {
+			continue
+		}
+		ok := true
+		for j := range complaints[i] {
+			ok = ok && justified[i][j]
+		}
+		if ok {
+			p.qual = append(p.qual, i)
+		}
+	}
+	return p.qual
+}
+
+// Extract broadcasts the Feldman commitment to the polynomial of the party,
+// if qualified
+func (p *Party) Extract() {
+	for _, i := range p.qual {
+		if i == p.Index {
+			p.bus.Broadcast(p.Index, &Extraction{p.dealer.FeldmanCommitment()})
+		}
+	}
+}
+
+// ComplainExtraction verifies the shares of the qualified dealers against
+// their extractions, and reveals the shares which do not match
+func (p *Party) ComplainExtraction() {
+	p.extractions = make(map[int]*vss.FeldmanCommitment)
+	for _, m := range p.bus.Broadcasts() {
+		if e, ok := m.Payload.(*Extraction); ok && e != nil && e.Commitment != nil && p.extractions[m.From] == nil && e.Commitment.Threshold() == p.t {
+			p.extractions[m.From] = e.Commitment
+		}
+	}
+	for _, i := range p.qual {
+		if e, ok := p.extractions[i]; ok && !vss.FeldmanVerify(e, p.shares[i]) {
+			p.bus.Broadcast(p.Index, &ExtractionComplaint{i, p.shares[i], p.blinds[i]})
+		}
+	}
+}
+
+// Reconstruct reveals the shares of the qualified dealers who did not
+// broadcast a valid extraction or against whom a complaint is valid
+func (p *Party) Reconstruct() {
+	p.reconstructed = make(map[int]bool)
+	for _, i := range p.qual {
+		if p.extractions[i] == nil {
+			p.reconstructed[i] = true
+		}
+	}
+	for _, m := range p.bus.Broadcasts() {
+		v, ok := m.Payload.(*ExtractionComplaint)
+		if !ok || v == nil || !wellFormed(v.Share, v.Blind) || p.deals[v.Dealer] == nil || p.extractions[v.Dealer] == nil || v.Share.Index != m.From {
+			continue
+		}
+		if vss.PedersenVerify(p.deals[v.Dealer], v.Share, v.Blind) && !vss.FeldmanVerify(p.extractions[v.Dealer], v.Share) {
+			p.reconstructed[v.Dealer] = true
+		}
+	}
+	for _, i := range p.qual {
+		if p.reconstructed[i] {
+			p.bus.Broadcast(p.Index, &Reconstruction{i, p.shares[i], p.blinds[i]})
+		}
+	}
+}
+
+// Result returns the key share of the party: xⱼ = Σ_{i ∈ QUAL} sᵢⱼ, and the
+// group key Σ_{i ∈ QUAL} [zᵢ]G, with [zᵢ]G reconstructed in the clear for
+// the dealers who cheated in the extraction
+func (p *Party) Result() (*KeyShare, error) {
+	revealed := make(map[int][]*vss.Share)
+	for _, m := range p.bus.Broadcasts() {
+		v, ok := m.Payload.(*Reconstruction)
+		if ok && v != nil && wellFormed(v.Share, v.Blind) && p.reconstructed[v.Dealer] && v.Share.Index == m.From && vss.PedersenVerify(p.deals[v.Dealer], v.Share, v.Blind) {
+			revealed[v.Dealer] = append(revealed[v.Dealer], v.Share)
+		}
+	}
+	x := primitives.BN254.NewElement(0)
+	y := new(bn256.G1).ScalarBaseMult(new(big.Int))
+	for _, i := range p.qual {
+		x = new(mod.Int).Add(x, p.shares[i].Value).(*mod.Int)
+		if !p.reconstructed[i] {
+			y.Add(y, p.extractions[i].PublicKey())
+			continue
+		}
+		z, err := vss.Reconstruct(revealed[i], p.t)
+		if err != nil {
+			return nil, fmt.Errorf("reconstructing the secret of %d: %v", i, err)
+		}
+		y.Add(y, new(bn256.G1).ScalarBaseMult(&z.V))
+	}
+	return &KeyShare{Qual: p.qual, GroupKey: y, Share: &vss.Share{Index: p.Index, Value: x}}, nil
+}
+
+// wellFormed reports whether a share and its blinding share received from
+// another party have all their fields
+func wellFormed(share, blind *vss.Share) bool {
+	return share != nil && share.Value != nil && blind != nil && blind.Value != nil
+}
+
+// Run runs all the phases of the protocol for the parties, in lockstep
+func Run(parties []*Party) ([]*KeyShare, error) {
+	phases := []func(*Party){
+		(*Party).Deal,
+		(*Party).Complain,
+		(*Party).Justify,
+		func(p *Party) { p.Qualify() },
+		(*Party).Extract,
+		(*Party).ComplainExtraction,
+		(*Party).Reconstruct,
+	}
+	for _, phase := range phases {
+		for _, p := range parties {
+			phase(p)
+		}
+	}
+	keys := make([]*KeyShare, len(parties))
+	for i, p := range parties {
+		k, err := p.Result()
+		if err != nil {
+			return nil, fmt.Errorf("party %d: %v", p.Index, err)
+		}
+		keys[i] = k
+	}
+	return keys, nil
+}
@@ -0,0 +1,173 @@
+package dkg
+
+import (
+	"commitment/primitives"
+	"commitment/vss"
+	"github.com/drand/kyber/group/mod"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newParties(t *testing.T, threshold, n int) (*Bus, []*Party) {
+	bus := NewBus()
+	parties := make([]*Party, n)
+	for i := range parties {
+		p, err := NewParty(bus, i+1, threshold, n)
+		assert.Nil(t, err)
+		parties[i] = p
+	}
+	return bus, parties
+}
+
+// checkKeys checks that the parties agree on the qualified dealers and the
+// group key, and that t+1 key shares reconstruct the group secret
+func checkKeys(t *testing.T, keys []*KeyShare, threshold int, qual []int) {
+	for _, k := range keys {
+		assert.Equal(t, qual, k.Qual)
+		assert.Equal(t, keys[0].GroupKey.Marshal(), k.GroupKey.Marshal())
+	}
+	shares := make([]*vss.Share, len(keys))
+	for i, k := range keys {
+		shares[i] = k.Share
+	}
+	x, err := vss.Reconstruct(shares[len(shares)-threshold-1:], threshold)
+	assert.Nil(t, err)
+	assert.Equal(t, new(bn256.G1).ScalarBaseMult(&x.V).Marshal(), keys[0].GroupKey.Marshal())
+}
+
+func TestRun(t *testing.T) {
+	_, parties := newParties(t, 2, 5)
+	keys, err := Run(parties)
+	assert.Nil(t, err)
+	checkKeys(t, keys, 2, []int{1, 2, 3, 4, 5})
+
+	_, err = NewParty(NewBus(), 0, 2, 5)
+	assert.NotNil(t, err)
+	_, err = NewParty(NewBus(), 1, 5, 5)
+	assert.NotNil(t, err)
+}
+
+// corrupt replaces the share sent by dealer to the party to in the bus
+func corrupt(bus *Bus, dealer, to int) {
+	for _, m := range bus.inboxes[to] {
+		if s, ok := m.Payload.(*PrivateShare); ok && m.From == dealer {
+			value := new(mod.Int).Add(s.Share.Value, primitives.BN254.NewElement(1)).(*mod.Int)
+			m.Payload = &PrivateShare{&vss.Share{Index: to, Value: value}, s.Blind}
+		}
+	}
+}
+
+func TestJustifiedComplaint(t *testing.T) {
+	bus, parties := newParties(t, 2, 5)
+	for _, p := range parties {
+		p.Deal()
+	}
+	// the dealer 1 justifies itself, the party 3 adopts the broadcast share
+	corrupt(bus, 1, 3)
+	keys, err := runFrom(parties, false, nil)
+	assert.Nil(t, err)
+	checkKeys(t, keys, 2, []int{1, 2, 3, 4, 5})
+}
+
+// malform replaces the share sent by dealer to the party to in the bus
+func malform(bus *Bus, dealer, to int, payload interface{}) {
+	for _, m := range bus.inboxes[to] {
+		if _, ok := m.Payload.(*PrivateShare); ok && m.From == dealer {
+			m.Payload = payload
+		}
+	}
+}
+
+func TestMalformedPayloads(t *testing.T) {
+	bus, parties := newParties(t, 2, 5)
+	bus.Broadcast(5, &Deal{})
+	for _, p := range parties {
+		p.Deal()
+	}
+	// the dealer 1 justifies the share it sent without a value, the dealer
+	// 4 gets more than t complaints for malformed shares
+	blind := &vss.Share{Index: 2, Value: primitives.BN254.NewElement(1)}
+	malform(bus, 1, 2, &PrivateShare{&vss.Share{Index: 2}, blind})
+	malform(bus, 4, 1, &PrivateShare{nil, blind})
+	malform(bus, 4, 2, &PrivateShare{&vss.Share{Index: 2, Value: primitives.BN254.NewElement(1)}, nil})
+	malform(bus, 4, 5, (*PrivateShare)(nil))
+	// malformed broadcasts of the party 3 are ignored
+	bus.Broadcast(3, &Justification{Accuser: 2})
+	bus.Broadcast(3, &Extraction{})
+	bus.Broadcast(3, (*Complaint)(nil))
+	bus.Broadcast(3, &ExtractionComplaint{Dealer: 1, Share: &vss.Share{Index: 3}})
+	bus.Broadcast(3, &Reconstruction{Dealer: 1})
+
+	keys, err := runFrom(parties, false, nil)
+	assert.Nil(t, err)
+	checkKeys(t, keys, 2, []int{1, 2, 3, 5})
+}
+
+func TestDisqualification(t *testing.T) {
+	bus, parties := newParties(t, 2, 5)
+	for _, p := range parties {
+		p.Deal()
+	}
+	// the dealer 2 does not justify itself, the dealer 4 gets more than t
+	// complaints
+	corrupt(bus, 2, 1)
+	for _, j := range []int{1, 2, 5} {
+		corrupt(bus, 4, j)
+	}
+	keys, err := runFrom(parties, false, map[int]bool{2: true})
+	assert.Nil(t, err)
+	checkKeys(t, keys, 2, []int{1, 3, 5})
+}
+
+func TestCheatingExtraction(t *testing.T) {
+	_, parties := newParties(t, 1, 4)
+	for _, p := range parties {
+		p.Deal()
+	}
+	keys, err := runFrom(parties, true, nil)
+	assert.Nil(t, err)
+	checkKeys(t, keys, 1, []int{1, 2, 3, 4})
+	for _, p := range parties {
+		assert.Equal(t, map[int]bool{3: true}, p.reconstructed)
+	}
+}
+
+// runFrom runs the phases after Deal. The dealer 3 broadcasts a wrong
+// extraction if cheat, the dealers in silent do not justify themselves.
+func runFrom(parties []*Party, cheat bool, silent map[int]bool) ([]*KeyShare, error) {
+	for _, p := range parties {
+		p.Complain()
+	}
+	for _, p := range parties {
+		if !silent[p.Index] {
+			p.Justify()
+		}
+	}
+	for _, p := range parties {
+		p.Qualify()
+	}
+	for _, p := range parties {
+		if cheat && p.Index == 3 {
+			other, _ := vss.NewDealer(primitives.BN254.NewElement(7), p.t, p.n)
+			p.bus.Broadcast(p.Index, &Extraction{other.FeldmanCommitment()})
+			continue
+		}
+		p.Extract()
+	}
+	for _, p := range parties {
+		p.ComplainExtraction()
+	}
+	for _, p := range parties {
+		p.Reconstruct()
+	}
+	keys := make([]*KeyShare, len(parties))
+	for i, p := range parties {
+		k, err := p.Result()
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = k
+	}
+	return keys, nil
+}
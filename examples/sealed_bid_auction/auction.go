@@ -0,0 +1,180 @@
+package main
+
+import (
+	"commitment/pedersen_commitment"
+	"commitment/primitives"
+	"fmt"
+	"github.com/drand/kyber/group/mod"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"math/big"
+)
+
+// BidBits is the size of the bids, in [0, 2³²)
+const BidBits = 32
+
+// SealedBid is the public commitment of a bidder to their bid, with the
+// proof that the bid is in [0, 2^BidBits)
+type SealedBid struct {
+	Bidder     string
+	Commitment *bn256.G1
+	Range      *pedersen_commitment.RangeProof
+}
+
+// Opening is the bid with its blinding factor, sent privately to the
+// auctioneer
+type Opening struct {
+	Bid, Blind *mod.Int
+}
+
+// Outcome is the result published by the auctioneer: the opening of the
+// winning bid, and for each losing bid cⱼ the proof that c* - cⱼ opens to
+// a value in [0, 2^BidBits), that is v* ≥ vⱼ, without revealing vⱼ
+type Outcome struct {
+	Winner   string
+	Price    *mod.Int
+	Blind    *mod.Int
+	Bids     []*SealedBid
+	Ordering map[string]*pedersen_commitment.RangeProof
+}
+
+// Seal commits to the bid of the bidder
+func Seal(pc *pedersen_commitment.Commiter, bidder string, bid uint64) (*SealedBid, *Opening, error) {
+	v := primitives.BN254.NewElementFromBig(new(big.Int).SetUint64(bid))
+	r, err := primitives.BN254.Rand()
+	if err != nil {
+		return nil, nil, err
+	}
+	proof, err := pc.ProveRange(v, r, BidBits, bidTranscript(bidder))
+	if err != nil {
+		return nil, nil, err
+	}
+	return &SealedBid{bidder, pc.Commit(v, r), proof}, &Opening{v, r}, nil
+}
+
+// Auctioneer collects the sealed bids and their openings
+type Auctioneer struct {
+	pc       *pedersen_commitment.Commiter
+	bids     []*SealedBid
+	openings map[string]*Opening
+}
+
+// NewAuctioneer returns an auctioneer with no bid
+func NewAuctioneer(pc *pedersen_commitment.Commiter) *Auctioneer {
+	return &Auctioneer{pc: pc, openings: make(map[string]*Opening)}
+}
+
+// Submit accepts the sealed bid if its range proof is valid and the opening
+// matches the commitment
+func (a *Auctioneer) Submit(bid *SealedBid, opening *Opening) error {
+	if bid == nil || opening == nil || opening.Bid == nil || opening.Blind == nil {
+		return fmt.Errorf("incomplete bid")
+	}
+	if _, ok := a.openings[bid.Bidder]; ok {
+		return fmt.Errorf("%s already bid", bid.Bidder)
+	}
+	if !a.pc.VerifyRange(bid.Commitment, BidBits, bid.Range, bidTranscript(bid.Bidder)) {
+		return fmt.Errorf("the range proof of %s is invalid", bid.Bidder)
+	}
+	if !a.pc.Verify(bid.Commitment, opening.Bid, opening.Blind) {
+		return fmt.Errorf("the opening of %s does not match their commitment", bid.Bidder)
+	}
+	a.bids = append(a.bids, bid)
+	a.openings[bid.Bidder] = opening
+	return nil
+}
+
+// Close determines the highest bid, the first one submitted on ties, and
+// proves that it is at least every other bid
+func (a *Auctioneer) Close() (*Outcome, error) {
+	if len(a.bids) == 0 {
+		return nil, fmt.Errorf("no bid")
+	}
+	winner := a.bids[0]
+	for _, b := range a.bids[1:] {
+		if a.openings[b.Bidder].Bid.V.Cmp(&a.openings[winner.Bidder].Bid.V) > 0 {
+			winner = b
+		}
+	}
+	w := a.openings[winner.Bidder]
+	out := &Outcome{
+		Winner:   winner.Bidder,
+		Price:    w.Bid,
+		Blind:    w.Blind,
+		Bids:     a.bids,
+		Ordering: make(map[string]*pedersen_commitment.RangeProof),
+	}
+	for _, b := range a.bids {
+		if b == winner {
+			continue
+		}
+		// c* - cⱼ commits to v* - vⱼ with the blinding factor r* - rⱼ
+		o := a.openings[b.Bidder]
+		d := new(mod.Int).Sub(w.Bid, o.Bid).(*mod.Int)
+		rd := new(mod.Int).Sub(w.Blind, o.Blind).(*mod.Int)
+		proof, err := a.pc.ProveRange(d, rd, BidBits, orderingTranscript(winner.Bidder, b.Bidder))
+		if err != nil {
+			return nil, err
+		}
+		out.Ordering[b.Bidder] = proof
+	}
+	return out, nil
+}
+
+// VerifyOutcome lets anybody check the outcome from the public bids: every
+// bid is in range, the winning commitment opens to the price, and the
+// winning bid is at least every other bid
+func VerifyOutcome(pc *pedersen_commitment.Commiter, out *Outcome) error {
+	if out == nil || out.Price == nil || out.Blind == nil {
+		return fmt.Errorf("incomplete outcome")
+	}
+	var winner *SealedBid
+	seen := make(map[string]bool)
+	for _, b := range out.Bids {
+		if b == nil {
+			return fmt.Errorf("incomplete bid")
+		}
+		if seen[b.Bidder] {
+			return fmt.Errorf("%s bid twice", b.Bidder)
+		}
+		seen[b.Bidder] = true
+		if !pc.VerifyRange(b.Commitment, BidBits, b.Range, bidTranscript(b.Bidder)) {
+			return fmt.Errorf("the range proof of %s is invalid", b.Bidder)
+		}
+		if b.Bidder == out.Winner {
+			winner = b
+		}
+	}
+	if winner == nil {
+		return fmt.Errorf("the winner %s did not bid", out.Winner)
+	}
+	if !pc.Verify(winner.Commitment, out.Price, out.Blind) {
+		return fmt.Errorf("the price does not open the commitment of %s", out.Winner)
+	}
+	for _, b := range out.Bids {
+		if b == winner {
+			continue
+		}
+		proof, ok := out.Ordering[b.Bidder]
+		if !ok {
+			return fmt.Errorf("no ordering proof for %s", b.Bidder)
+		}
+		d := pedersen_commitment.Sub(winner.Commitment, b.Commitment)
+		if !pc.VerifyRange(d, BidBits, proof, orderingTranscript(winner.Bidder, b.Bidder)) {
+			return fmt.Errorf("the bid of %s is not proven lower than the winning bid", b.Bidder)
+		}
+	}
+	return nil
+}
+
+func bidTranscript(bidder string) *primitives.Transcript {
+	tr := primitives.NewTranscript("sealed bid auction: bid")
+	tr.AppendBytes("bidder", []byte(bidder))
+	return tr
+}
+
+func orderingTranscript(winner, loser string) *primitives.Transcript {
+	tr := primitives.NewTranscript("sealed bid auction: ordering")
+	tr.AppendBytes("winner", []byte(winner))
+	tr.AppendBytes("loser", []byte(loser))
+	return tr
+}
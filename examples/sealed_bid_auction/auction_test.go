@@ -0,0 +1,76 @@
+package main
+
+import (
+	"commitment/pedersen_commitment"
+	"commitment/primitives"
+	"github.com/drand/kyber/group/mod"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func runAuction(t *testing.T, pc *pedersen_commitment.Commiter, bids map[string]uint64, order []string) *Outcome {
+	a := NewAuctioneer(pc)
+	for _, name := range order {
+		sealed, opening, err := Seal(pc, name, bids[name])
+		assert.Nil(t, err)
+		assert.Nil(t, a.Submit(sealed, opening))
+	}
+	out, err := a.Close()
+	assert.Nil(t, err)
+	return out
+}
+
+func TestAuction(t *testing.T) {
+	pc := pedersen_commitment.NewCommiter()
+	out := runAuction(t, pc, map[string]uint64{"alice": 120, "bob": 340, "carol": 275}, []string{"alice", "bob", "carol"})
+	assert.Equal(t, "bob", out.Winner)
+	assert.True(t, out.Price.Equal(primitives.BN254.NewElement(340)))
+	assert.Nil(t, VerifyOutcome(pc, out))
+
+	// the auctioneer cannot name another winner
+	cheat := *out
+	cheat.Winner = "carol"
+	assert.NotNil(t, VerifyOutcome(pc, &cheat))
+	// nor change the price
+	cheat = *out
+	cheat.Price = primitives.BN254.NewElement(300)
+	assert.NotNil(t, VerifyOutcome(pc, &cheat))
+	// nor drop an ordering proof
+	cheat = *out
+	cheat.Ordering = map[string]*pedersen_commitment.RangeProof{"alice": out.Ordering["alice"]}
+	assert.NotNil(t, VerifyOutcome(pc, &cheat))
+	// nor give a malformed one
+	cheat.Ordering = map[string]*pedersen_commitment.RangeProof{"alice": out.Ordering["alice"], "carol": nil}
+	assert.NotNil(t, VerifyOutcome(pc, &cheat))
+}
+
+func TestTie(t *testing.T) {
+	pc := pedersen_commitment.NewCommiter()
+	out := runAuction(t, pc, map[string]uint64{"alice": 50, "bob": 50}, []string{"alice", "bob"})
+	assert.Equal(t, "alice", out.Winner)
+	assert.Nil(t, VerifyOutcome(pc, out))
+}
+
+func TestSubmit(t *testing.T) {
+	pc := pedersen_commitment.NewCommiter()
+	a := NewAuctioneer(pc)
+	sealed, opening, err := Seal(pc, "alice", 10)
+	assert.Nil(t, err)
+	// the range proof is bound to the bidder
+	stolen := *sealed
+	stolen.Bidder = "mallory"
+	assert.NotNil(t, a.Submit(&stolen, opening))
+	assert.NotNil(t, a.Submit(sealed, &Opening{primitives.BN254.NewElement(11), opening.Blind}))
+	// malformed bids are rejected
+	assert.NotNil(t, a.Submit(&SealedBid{"mallory", sealed.Commitment, nil}, opening))
+	assert.NotNil(t, a.Submit(&SealedBid{"mallory", sealed.Commitment, &pedersen_commitment.RangeProof{}}, opening))
+	assert.NotNil(t, a.Submit(sealed, &Opening{}))
+	assert.NotNil(t, a.Submit(nil, opening))
+	assert.Nil(t, a.Submit(sealed, opening))
+	assert.NotNil(t, a.Submit(sealed, opening))
+
+	// a negative bid wraps around the field and has no range proof
+	r, _ := primitives.BN254.Rand()
+	_, err = pc.ProveRange(new(mod.Int).Neg(primitives.BN254.NewElement(1)).(*mod.Int), r, BidBits, bidTranscript("mallory"))
+	assert.NotNil(t, err)
+}
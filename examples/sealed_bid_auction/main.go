@@ -0,0 +1,43 @@
+// Command sealed_bid_auction runs a sealed bid auction: the bidders commit
+// to their bids with Pedersen commitments and prove them in range, the
+// auctioneer opens the winning bid only, and proves with range proofs on the
+// differences of the commitments that it is the highest, so that anybody
+// can verify the outcome while the losing bids stay hidden.
+package main
+
+import (
+	"commitment/pedersen_commitment"
+	"fmt"
+	"os"
+)
+
+func main() {
+	pc := pedersen_commitment.NewCommiter()
+	auctioneer := NewAuctioneer(pc)
+	for _, b := range []struct {
+		name string
+		bid  uint64
+	}{{"alice", 120}, {"bob", 340}, {"carol", 275}} {
+		sealed, opening, err := Seal(pc, b.name, b.bid)
+		if err == nil {
+			err = auctioneer.Submit(sealed, opening)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Printf("%s bids %x\n", b.name, sealed.Commitment.Marshal()[:8])
+	}
+
+	outcome, err := auctioneer.Close()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Printf("%s wins at %s\n", outcome.Winner, outcome.Price.V.String())
+	if err := VerifyOutcome(pc, outcome); err != nil {
+		fmt.Fprintln(os.Stderr, "invalid outcome:", err)
+		os.Exit(1)
+	}
+	fmt.Println("the outcome is valid")
+}
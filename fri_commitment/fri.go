@@ -0,0 +1,358 @@
+package fri_commitment
+
+import (
+	"commitment/primitives"
+	"encoding/binary"
+	"fmt"
+	"github.com/drand/kyber/group/mod"
+)
+
+// Params configures FRI
+type Params struct {
+	Field primitives.Field
+	// BlowupFactor is the inverse rate of the Reed–Solomon code, the size of
+	// the evaluation domain over the number of coefficients
+	BlowupFactor int
+	// FoldingFactor is the factor by which each round reduces the degree
+	FoldingFactor int
+	// Queries is the number of queries checked by the verifier, each adds
+	// about log₂(BlowupFactor) bits of security
+	Queries int
+	// Shift is the offset of the evaluation domain, a coset of the roots of
+	// unity. It must not be a root of unity of the domain size. Nil means 5.
+	Shift *mod.Int
+}
+
+// DefaultParams returns the parameters over the BN254 scalar field with a
+// blowup factor of 4, a folding factor of 2 and 40 queries
+func DefaultParams() Params {
+	return Params{Field: primitives.BN254, BlowupFactor: 4, FoldingFactor: 2, Queries: 40}
+}
+
+// Setup holds the domains of the folding layers. There is no secret in it.
+type Setup struct {
+	Params
+	D       int        // the number of coefficients of the committed polynomials
+	layers  []*layer   // the domains of the rounds, the last one of the final polynomial
+	zetaInv []*mod.Int // ζ⁻ʲ for ζ a primitive k-th root of unity
+	kInv    *mod.Int
+	size    int // size of a serialized field element
+}
+
+// layer is the coset shift·⟨ω⟩ of size n of a round, and d the number of
+// coefficients of the polynomial of the round
+type layer struct {
+	n, d         int
+	shift, omega *mod.Int
+}
+
+// NewSetup prepares the commitment of polynomials of up to d coefficients,
+// d a power of two not smaller than the folding factor
+func NewSetup(params Params, d int) (*Setup, error) {
+	f, k := params.Field, params.FoldingFactor
+	if !isPowerOfTwo(d) || !isPowerOfTwo(params.BlowupFactor) || !isPowerOfTwo(k) {
+		return nil, fmt.Errorf("the degree bound, blowup factor and folding factor must be powers of two")
+	}
+	if params.BlowupFactor < 2 || k < 2 || d < k {
+		return nil, fmt.Errorf("need a blowup factor and a folding factor of at least 2 and d ≥ k")
+	}
+	if params.Queries < 1 {
+		return nil, fmt.Errorf("need at least one query, got %d", params.Queries)
+	}
+	if params.Shift == nil {
+		params.Shift = f.NewElement(5)
+	}
+	s := &Setup{Params: params, D: d, size: (f.Modulus().BitLen() + 7) / 8}
+
+	shift, n := params.Shift, d*params.BlowupFactor
+	for {
+		omega, err := f.RootOfUnity(n)
+		if err != nil {
+			return nil, err
+		}
+		s.layers = append(s.layers, &layer{n, d, shift, omega})
+		if d < k {
+			break
+		}
+		n, d = n/k, d/k
+		shift = new(mod.Int).Exp(shift, bigInt(k)).(*mod.Int)
+	}
+	// the shift must not be in the domain: shiftⁿ ≠ 1
+	if new(mod.Int).Exp(params.Shift, bigInt(s.layers[0].n)).Equal(f.NewElement(1)) {
+		return nil, fmt.Errorf("the shift %s is in the evaluation domain", params.Shift.String())
+	}
+	zeta, err := f.RootOfUnity(k)
+	if err != nil {
+		return nil, err
+	}
+	zetaInv := new(mod.Int).Inv(zeta).(*mod.Int)
+	s.zetaInv = make([]*mod.Int, k)
+	s.zetaInv[0] = f.NewElement(1)
+	for j := 1; j < k; j++ {
+		s.zetaInv[j] = new(mod.Int).Mul(s.zetaInv[j-1], zetaInv).(*mod.Int)
+	}
+	s.kInv = new(mod.Int).Inv(f.NewElement(int64(k))).(*mod.Int)
+	return s, nil
+}
+
+// rounds returns the number of folding rounds
+func (s *Setup) rounds() int {
+	return len(s.layers) - 1
+}
+
+// point returns the point i of the domain of the round l
+func (s *Setup) point(l, i int) *mod.Int {
+	return new(mod.Int).Mul(s.layers[l].shift, new(mod.Int).Exp(s.layers[l].omega, bigInt(i))).(*mod.Int)
+}
+
+// codeword evaluates the polynomial over the domain of the round l
+func (s *Setup) codeword(l int, coeffs []*mod.Int) ([]*mod.Int, error) {
+	scaled := make([]*mod.Int, len(coeffs))
+	shiftPow := s.Field.NewElement(1)
+	for i := range coeffs {
+		scaled[i] = new(mod.Int).Mul(coeffs[i], shiftPow).(*mod.Int)
+		shiftPow = new(mod.Int).Mul(shiftPow, s.layers[l].shift).(*mod.Int)
+	}
+	return primitives.NTT(scaled, s.layers[l].n)
+}
+
+// cosets groups the codeword of the round l by the cosets folded together,
+// the leaf c holds the evaluations at the indexes c + j·n/k
+func (s *Setup) cosets(l int, evals []*mod.Int) ([][]*mod.Int, [][]byte) {
+	k := s.FoldingFactor
+	m := s.layers[l].n / k
+	values := make([][]*mod.Int, m)
+	leaves := make([][]byte, m)
+	for c := range values {
+		values[c] = make([]*mod.Int, k)
+		for j := range values[c] {
+			values[c][j] = evals[c+j*m]
+		}
+		leaves[c] = s.leaf(values[c])
+	}
+	return values, leaves
+}
+
+func (s *Setup) leaf(values []*mod.Int) []byte {
+	b := make([]byte, len(values)*s.size)
+	for j, v := range values {
+		v.V.FillBytes(b[j*s.size : (j+1)*s.size])
+	}
+	return b
+}
+
+// fold returns f'(x₀ᵏ) = Σⱼ αʲfⱼ(x₀ᵏ) for f(x) = Σⱼ xʲfⱼ(xᵏ) given the
+// values f(x₀ζᵐ) of the coset c of the round l, by the inverse DFT
+//
+//	fⱼ(x₀ᵏ) = 1/(k·x₀ʲ) Σₘ f(x₀ζᵐ)ζ⁻ᵐʲ
+func (s *Setup) fold(l, c int, values []*mod.Int, alpha *mod.Int) *mod.Int {
+	k := s.FoldingFactor
+	// α/x₀
+	a := new(mod.Int).Div(alpha, s.point(l, c)).(*mod.Int)
+	aPow := s.Field.NewElement(1)
+	r := s.Field.NewElement(0)
+	for j := 0; j < k; j++ {
+		fj := s.Field.NewElement(0)
+		for m := 0; m < k; m++ {
+			fj = new(mod.Int).Add(fj, new(mod.Int).Mul(values[m], s.zetaInv[m*j%k])).(*mod.Int)
+		}
+		r = new(mod.Int).Add(r, new(mod.Int).Mul(aPow, fj)).(*mod.Int)
+		aPow = new(mod.Int).Mul(aPow, a).(*mod.Int)
+	}
+	return new(mod.Int).Mul(r, s.kInv).(*mod.Int)
+}
+
+// foldCoefficients returns the coefficients of Σⱼ αʲfⱼ(x)
+func (s *Setup) foldCoefficients(coeffs []*mod.Int, alpha *mod.Int) []*mod.Int {
+	k := s.FoldingFactor
+	r := make([]*mod.Int, len(coeffs)/k)
+	for i := range r {
+		r[i] = s.Field.NewElement(0)
+		aPow := s.Field.NewElement(1)
+		for j := 0; j < k; j++ {
+			r[i] = new(mod.Int).Add(r[i], new(mod.Int).Mul(aPow, coeffs[i*k+j])).(*mod.Int)
+			aPow = new(mod.Int).Mul(aPow, alpha).(*mod.Int)
+		}
+	}
+	return r
+}
+
+// queryIndexes squeezes the cosets of the first round checked by the verifier
+func (s *Setup) queryIndexes(tr *primitives.Transcript) []int {
+	m := uint64(s.layers[0].n / s.FoldingFactor)
+	is := make([]int, s.Queries)
+	for q := range is {
+		is[q] = int(binary.BigEndian.Uint64(tr.ChallengeBytes("query")[:8]) % m)
+	}
+	return is
+}
+
+//
+// Low degree test
+//
+
+// Decommitment opens a coset of a round: its values and Merkle path
+type Decommitment struct {
+	Values []*mod.Int
+	Path   [][32]byte
+}
+
+// Proof is a FRI proof that a committed codeword is the evaluation of a
+// polynomial of less than D coefficients
+type Proof struct {
+	// Roots are the Merkle roots of the rounds after the first one, whose
+	// codeword is committed separately
+	Roots [][32]byte
+	// Final holds the coefficients of the polynomial of the last round
+	Final []*mod.Int
+	// Queries holds, for each query, the cosets opened in each round
+	Queries [][]*Decommitment
+}
+
+// ProveLowDegree proves that the codeword committed by Commit(s, p) is of
+// degree less than D
+func ProveLowDegree(s *Setup, p *primitives.Polynomial, tr *primitives.Transcript) (*Proof, error) {
+	values, tree, err := s.commitTree(p)
+	if err != nil {
+		return nil, err
+	}
+	coeffs, _ := s.coefficients(p)
+	root := tree.root()
+	tr.AppendBytes("root", root[:])
+	return s.prove(coeffs, values, tree, tr)
+}
+
+// VerifyLowDegree verifies that the codeword committed in c is close to a
+// polynomial of less than D coefficients
+func VerifyLowDegree(s *Setup, c *Commitment, proof *Proof, tr *primitives.Transcript) bool {
+	tr.AppendBytes("root", c.Root[:])
+	return s.verify(c.Root, proof, tr, func(_ int, values []*mod.Int) []*mod.Int {
+		return values
+	})
+}
+
+// coefficients returns the coefficients of p padded to D
+func (s *Setup) coefficients(p *primitives.Polynomial) ([]*mod.Int, error) {
+	if len(p.Coefficient) > s.D {
+		return nil, fmt.Errorf("the setup supports polynomials of %d coefficients, got %d", s.D, len(p.Coefficient))
+	}
+	coeffs := make([]*mod.Int, s.D)
+	copy(coeffs, p.Coefficient)
+	for i := len(p.Coefficient); i < s.D; i++ {
+		coeffs[i] = s.Field.NewElement(0)
+	}
+	return coeffs, nil
+}
+
+// prove runs the folding rounds on the polynomial of coefficients coeffs.
+// The first round opens the cosets values0 committed in tree0, which is
+// already bound to the transcript.
+func (s *Setup) prove(coeffs []*mod.Int, values0 [][]*mod.Int, tree0 *merkleTree, tr *primitives.Transcript) (*Proof, error) {
+	values := [][][]*mod.Int{values0}
+	trees := []*merkleTree{tree0}
+	proof := &Proof{}
+	for l := 0; l < s.rounds(); l++ {
+		alpha := tr.ChallengeScalar("alpha", s.Field)
+		coeffs = s.foldCoefficients(coeffs, alpha)
+		if l+1 == s.rounds() {
+			break
+		}
+		evals, err := s.codeword(l+1, coeffs)
+		if err != nil {
+			return nil, err
+		}
+		v, leaves := s.cosets(l+1, evals)
+		tree := newMerkleTree(leaves)
+		root := tree.root()
+		tr.AppendBytes("root", root[:])
+		proof.Roots = append(proof.Roots, root)
+		values, trees = append(values, v), append(trees, tree)
+	}
+	proof.Final = coeffs
+	tr.AppendScalars("final", coeffs)
+
+	for _, c := range s.queryIndexes(tr) {
+		query := make([]*Decommitment, s.rounds())
+		for l := range query {
+			query[l] = &Decommitment{values[l][c], trees[l].path(c)}
+			// the folded value is at the index c of the next round
+			if l+1 < s.rounds() {
+				c %= s.layers[l+1].n / s.FoldingFactor
+			}
+		}
+		proof.Queries = append(proof.Queries, query)
+	}
+	return proof, nil
+}
+
+// verify checks the folding rounds of the proof. The cosets of the first
+// round are opened against root and mapped by values0 to the values of the
+// polynomial tested, nil rejecting the proof.
+func (s *Setup) verify(root [32]byte, proof *Proof, tr *primitives.Transcript, values0 func(c int, values []*mod.Int) []*mod.Int) bool {
+	if proof == nil || len(proof.Roots) != s.rounds()-1 || len(proof.Final) != s.layers[s.rounds()].d || len(proof.Queries) != s.Queries {
+		return false
+	}
+	alphas := make([]*mod.Int, s.rounds())
+	for l := range alphas {
+		alphas[l] = tr.ChallengeScalar("alpha", s.Field)
+		if l+1 < s.rounds() {
+			tr.AppendBytes("root", proof.Roots[l][:])
+		}
+	}
+	if !s.canonical(proof.Final) {
+		return false
+	}
+	tr.AppendScalars("final", proof.Final)
+	final := new(primitives.Polynomial).Init(proof.Final)
+
+	k := s.FoldingFactor
+	for q, c := range s.queryIndexes(tr) {
+		query := proof.Queries[q]
+		if len(query) != s.rounds() {
+			return false
+		}
+		var folded *mod.Int
+		for l, dec := range query {
+			r, m := root, s.layers[l].n/k
+			if l > 0 {
+				r = proof.Roots[l-1]
+			}
+			if dec == nil || len(dec.Values) != k || !s.canonical(dec.Values) || !verifyPath(r, m, c%m, s.leaf(dec.Values), dec.Path) {
+				return false
+			}
+			values := dec.Values
+			if l == 0 {
+				if values = values0(c, values); values == nil {
+					return false
+				}
+			} else {
+				// the value folded in the previous round is at the index c
+				i := c
+				c = i % m
+				if !values[i/m].Equal(folded) {
+					return false
+				}
+			}
+			folded = s.fold(l, c, values, alphas[l])
+		}
+		// the last folded value is at the index c of the final domain
+		if !final.Eval(s.point(s.rounds(), c)).Equal(folded) {
+			return false
+		}
+	}
+	return true
+}
+
+// canonical checks that the values are reduced field elements
+func (s *Setup) canonical(values []*mod.Int) bool {
+	for _, v := range values {
+		if v == nil || v.V.Sign() < 0 || v.V.Cmp(s.Field.Modulus()) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func isPowerOfTwo(n int) bool {
+	return n > 0 && n&(n-1) == 0
+}
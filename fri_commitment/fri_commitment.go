@@ -0,0 +1,114 @@
+// Package fri_commitment implements a transparent, plausibly post-quantum
+// polynomial commitment on the FRI low degree test: a polynomial is committed
+// by the Merkle root of its Reed–Solomon codeword over a coset of the roots
+// of unity, and p(z) = y is proven by showing that (p(x) - y)/(x - z) is of
+// low degree. https://eccc.weizmann.ac.il/report/2017/134/
+package fri_commitment
+
+import (
+	"commitment/primitives"
+	"fmt"
+	"github.com/drand/kyber/group/mod"
+	"math/big"
+)
+
+// Commitment is the Merkle root of the codeword of a polynomial, the leaves
+// being the cosets folded together by the first round
+type Commitment struct {
+	Root [32]byte
+}
+
+// Commit generates the commitment to the polynomial p(x)
+func Commit(s *Setup, p *primitives.Polynomial) (*Commitment, error) {
+	_, tree, err := s.commitTree(p)
+	if err != nil {
+		return nil, err
+	}
+	return &Commitment{tree.root()}, nil
+}
+
+func (s *Setup) commitTree(p *primitives.Polynomial) ([][]*mod.Int, *merkleTree, error) {
+	coeffs, err := s.coefficients(p)
+	if err != nil {
+		return nil, nil, err
+	}
+	evals, err := s.codeword(0, coeffs)
+	if err != nil {
+		return nil, nil, err
+	}
+	values, leaves := s.cosets(0, evals)
+	return values, newMerkleTree(leaves), nil
+}
+
+// EvaluationProof generates the proof of p(z) = y: a FRI proof of the
+// quotient q(x) = (p(x) - y)/(x - z), whose values the verifier computes
+// from the opened values of p. The degree of q is corrected to D - 1 with
+// q(x)·(1 + r·x) for a random r, so that D coefficients are tested.
+func EvaluationProof(s *Setup, p *primitives.Polynomial, z, y *mod.Int, tr *primitives.Transcript) (*Proof, error) {
+	if s.inDomain(z) {
+		return nil, fmt.Errorf("z is in the evaluation domain")
+	}
+	values, tree, err := s.commitTree(p)
+	if err != nil {
+		return nil, err
+	}
+	root := tree.root()
+	r := s.bind(tr, root, z, y)
+
+	// q(x) = (p(x) - y) / (x - z)
+	q, rem := new(primitives.Polynomial).DivByLinear(p, z)
+	if !rem.Equal(y) {
+		return nil, fmt.Errorf("p(z) is %s, not %s", rem.String(), y.String())
+	}
+	// q(x)·(1 + r·x)
+	qc, err := s.coefficients(q)
+	if err != nil {
+		return nil, err
+	}
+	coeffs := make([]*mod.Int, s.D)
+	coeffs[0] = qc[0]
+	for i := 1; i < s.D; i++ {
+		coeffs[i] = new(mod.Int).Add(qc[i], new(mod.Int).Mul(r, qc[i-1])).(*mod.Int)
+	}
+	return s.prove(coeffs, values, tree, tr)
+}
+
+// Verify verifies the proof of p(z) = y for the commitment c
+func Verify(s *Setup, c *Commitment, proof *Proof, z, y *mod.Int, tr *primitives.Transcript) bool {
+	if s.inDomain(z) {
+		return false
+	}
+	r := s.bind(tr, c.Root, z, y)
+	one := s.Field.NewElement(1)
+	return s.verify(c.Root, proof, tr, func(coset int, values []*mod.Int) []*mod.Int {
+		// x = x₀ζᵐ, the point of values[m]
+		m := s.layers[0].n / s.FoldingFactor
+		qs := make([]*mod.Int, len(values))
+		for j, v := range values {
+			x := s.point(0, coset+j*m)
+			// (p(x) - y)/(x - z)·(1 + r·x)
+			q := new(mod.Int).Div(new(mod.Int).Sub(v, y), new(mod.Int).Sub(x, z))
+			qs[j] = new(mod.Int).Mul(q, new(mod.Int).Add(one, new(mod.Int).Mul(r, x))).(*mod.Int)
+		}
+		return qs
+	})
+}
+
+// bind appends the statement to the transcript and returns the challenge of
+// the degree correction
+func (s *Setup) bind(tr *primitives.Transcript, root [32]byte, z, y *mod.Int) *mod.Int {
+	tr.AppendBytes("root", root[:])
+	tr.AppendScalar("z", z)
+	tr.AppendScalar("y", y)
+	return tr.ChallengeScalar("r", s.Field)
+}
+
+// inDomain checks whether z is in the coset shift·⟨ω⟩: zⁿ = shiftⁿ
+func (s *Setup) inDomain(z *mod.Int) bool {
+	n := bigInt(s.layers[0].n)
+	return new(mod.Int).Exp(z, n).Equal(new(mod.Int).Exp(s.layers[0].shift, n))
+}
+
+func bigInt(n int) *big.Int {
+	return big.NewInt(int64(n))
+}
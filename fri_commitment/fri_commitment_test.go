@@ -0,0 +1,59 @@
+package fri_commitment
+
+import (
+	"commitment/primitives"
+	"github.com/drand/kyber/group/mod"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSimpleFlow(t *testing.T) {
+	s, err := NewSetup(DefaultParams(), 16)
+	assert.Nil(t, err)
+	// p(x) = x^3 + x + 5
+	p := new(primitives.Polynomial).Init([]*mod.Int{
+		mod.NewInt64(5, primitives.Q),
+		mod.NewInt64(1, primitives.Q),
+		mod.NewInt64(0, primitives.Q),
+		mod.NewInt64(1, primitives.Q),
+	})
+	c, err := Commit(s, p)
+	assert.Nil(t, err)
+
+	z := mod.NewInt64(3, primitives.Q)
+	y := mod.NewInt64(35, primitives.Q)
+	proof, err := EvaluationProof(s, p, z, y, primitives.NewTranscript("test"))
+	assert.Nil(t, err)
+	assert.True(t, Verify(s, c, proof, z, y, primitives.NewTranscript("test")))
+
+	assert.False(t, Verify(s, c, proof, z, mod.NewInt64(36, primitives.Q), primitives.NewTranscript("test")))
+	assert.False(t, Verify(s, c, proof, mod.NewInt64(4, primitives.Q), y, primitives.NewTranscript("test")))
+	other, _ := Commit(s, randPolynomial(t, s.Field, 16))
+	assert.False(t, Verify(s, other, proof, z, y, primitives.NewTranscript("test")))
+
+	_, err = EvaluationProof(s, p, z, mod.NewInt64(36, primitives.Q), primitives.NewTranscript("test"))
+	assert.NotNil(t, err)
+	_, err = Commit(s, randPolynomial(t, s.Field, 17))
+	assert.NotNil(t, err)
+}
+
+func TestEvaluationProof_Goldilocks(t *testing.T) {
+	params := DefaultParams()
+	params.Field = primitives.Goldilocks
+	params.FoldingFactor = 4
+	params.BlowupFactor = 8
+	params.Queries = 20
+	s, err := NewSetup(params, 64)
+	assert.Nil(t, err)
+	p := randPolynomial(t, params.Field, 64)
+	c, err := Commit(s, p)
+	assert.Nil(t, err)
+	z := params.Field.NewElement(123456789)
+	proof, err := EvaluationProof(s, p, z, p.Eval(z), primitives.NewTranscript("test"))
+	assert.Nil(t, err)
+	assert.True(t, Verify(s, c, proof, z, p.Eval(z), primitives.NewTranscript("test")))
+
+	// tampering with an opened value breaks its Merkle path
+	proof.Queries[0][1].Values[0] = params.Field.NewElement(1)
+	assert.False(t, Verify(s, c, proof, z, p.Eval(z), primitives.NewTranscript("test")))
+}
@@ -0,0 +1,61 @@
+package fri_commitment
+
+import (
+	"commitment/primitives"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func randPolynomial(t *testing.T, f primitives.Field, n int) *primitives.Polynomial {
+	coeffs, err := f.RandVector(n)
+	assert.Nil(t, err)
+	return new(primitives.Polynomial).Init(coeffs)
+}
+
+func TestLowDegree(t *testing.T) {
+	for _, k := range []int{2, 4, 8} {
+		params := DefaultParams()
+		params.FoldingFactor = k
+		s, err := NewSetup(params, 32)
+		assert.Nil(t, err)
+		p := randPolynomial(t, params.Field, 32)
+		c, err := Commit(s, p)
+		assert.Nil(t, err)
+		proof, err := ProveLowDegree(s, p, primitives.NewTranscript("test"))
+		assert.Nil(t, err)
+		assert.True(t, VerifyLowDegree(s, c, proof, primitives.NewTranscript("test")), k)
+		assert.False(t, VerifyLowDegree(s, c, proof, primitives.NewTranscript("other")), k)
+	}
+}
+
+func TestLowDegree_HighDegree(t *testing.T) {
+	s, err := NewSetup(DefaultParams(), 16)
+	assert.Nil(t, err)
+	// the codeword of a polynomial of 32 coefficients
+	p := randPolynomial(t, s.Field, 32)
+	evals, err := s.codeword(0, p.Coefficient)
+	assert.Nil(t, err)
+	values, leaves := s.cosets(0, evals)
+	tree := newMerkleTree(leaves)
+	c := &Commitment{tree.root()}
+
+	// fold the first half of the coefficients as a cheating prover would
+	tr := primitives.NewTranscript("test")
+	tr.AppendBytes("root", c.Root[:])
+	proof, err := s.prove(p.Coefficient[:16], values, tree, tr)
+	assert.Nil(t, err)
+	assert.False(t, VerifyLowDegree(s, c, proof, primitives.NewTranscript("test")))
+}
+
+func TestNewSetup(t *testing.T) {
+	params := DefaultParams()
+	_, err := NewSetup(params, 12)
+	assert.NotNil(t, err)
+	params.FoldingFactor = 3
+	_, err = NewSetup(params, 16)
+	assert.NotNil(t, err)
+	params = DefaultParams()
+	params.Shift = params.Field.NewElement(1)
+	_, err = NewSetup(params, 16)
+	assert.NotNil(t, err)
+}
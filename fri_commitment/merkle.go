@@ -0,0 +1,69 @@
+package fri_commitment
+
+import (
+	"bytes"
+	"crypto/sha256"
+)
+
+// merkleTree is a binary sha256 Merkle tree over a power of two number of
+// leaves, nodes[1] is the root and the children of nodes[i] are nodes[2i]
+// and nodes[2i+1]
+type merkleTree struct {
+	nodes [][32]byte
+	n     int
+}
+
+func hashLeaf(leaf []byte) [32]byte {
+	return sha256.Sum256(append([]byte{0}, leaf...))
+}
+
+func hashNode(l, r [32]byte) [32]byte {
+	b := make([]byte, 0, 65)
+	b = append(b, 1)
+	b = append(b, l[:]...)
+	b = append(b, r[:]...)
+	return sha256.Sum256(b)
+}
+
+func newMerkleTree(leaves [][]byte) *merkleTree {
+	n := len(leaves)
+	t := &merkleTree{make([][32]byte, 2*n), n}
+	for i, leaf := range leaves {
+		t.nodes[n+i] = hashLeaf(leaf)
+	}
+	for i := n - 1; i >= 1; i-- {
+		t.nodes[i] = hashNode(t.nodes[2*i], t.nodes[2*i+1])
+	}
+	return t
+}
+
+func (t *merkleTree) root() [32]byte {
+	return t.nodes[1]
+}
+
+// path returns the siblings from the leaf i up to the root
+func (t *merkleTree) path(i int) [][32]byte {
+	var path [][32]byte
+	for j := t.n + i; j > 1; j /= 2 {
+		path = append(path, t.nodes[j^1])
+	}
+	return path
+}
+
+// verifyPath checks that leaf is the leaf i of the tree of n leaves
+func verifyPath(root [32]byte, n, i int, leaf []byte, path [][32]byte) bool {
+	h := hashLeaf(leaf)
+	j := n + i
+	for _, sibling := range path {
+		if j <= 1 {
+			return false
+		}
+		if j%2 == 0 {
+			h = hashNode(h, sibling)
+		} else {
+			h = hashNode(sibling, h)
+		}
+		j /= 2
+	}
+	return j == 1 && bytes.Equal(h[:], root[:])
+}
@@ -0,0 +1,20 @@
+package fri_commitment
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMerkleTree(t *testing.T) {
+	leaves := [][]byte{[]byte("a"), []byte("b"), []byte("c"), []byte("d")}
+	tree := newMerkleTree(leaves)
+	for i, leaf := range leaves {
+		assert.True(t, verifyPath(tree.root(), len(leaves), i, leaf, tree.path(i)))
+		assert.False(t, verifyPath(tree.root(), len(leaves), (i+1)%4, leaf, tree.path(i)))
+	}
+	assert.False(t, verifyPath(tree.root(), len(leaves), 0, []byte("b"), tree.path(0)))
+	assert.False(t, verifyPath(tree.root(), len(leaves), 0, []byte("a"), tree.path(0)[1:]))
+
+	single := newMerkleTree(leaves[:1])
+	assert.True(t, verifyPath(single.root(), 1, 0, leaves[0], single.path(0)))
+}
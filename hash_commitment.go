@@ -10,8 +10,20 @@ import (
 type hash_commiter struct {
 }
 
+// HashCommiter is the SHA-256 commitment c = H(x || r)
+type HashCommiter = hash_commiter
+
+// NonceSize is the size of the nonces r. It is fixed so that the bytes of
+// x || r cannot be moved between x and r to open c to another value.
+const NonceSize = 32
+
+// NewHashCommiter returns a SHA-256 hash commiter
+func NewHashCommiter() HashCommiter {
+	return hash_commiter{}
+}
+
 func (hc hash_commiter) Setup() []byte {
-	r := make([]byte, 32)
+	r := make([]byte, NonceSize)
 	_, err := rand.Read(r)
 	if err != nil {
 		fmt.Println("error:", err)
@@ -21,15 +33,18 @@ func (hc hash_commiter) Setup() []byte {
 
 func (hc hash_commiter) Commit(x []byte, r []byte) []byte {
 
-	// The slice should now contain random bytes instead of only zeroes.
-	vR := append(x, r...)
+	// x || r in a new slice, appending to x could overwrite the bytes after it
+	vR := append(append([]byte{}, x...), r...)
 	c := sha256.Sum256(vR)
 	return c[:]
 }
 
 func (hc hash_commiter) Verify(x []byte, r []byte, c []byte) bool {
+	if len(r) != NonceSize {
+		return false
+	}
 
-	vR := append(x, r...)
+	vR := append(append([]byte{}, x...), r...)
 	cc := sha256.Sum256(vR)
 	if bytes.Compare(c, cc[:]) == 0 {
 		return true
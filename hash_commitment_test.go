@@ -14,3 +14,27 @@ func TestVerify(t *testing.T) {
 	assert.Equal(t, true, hc.Verify(value, r, c))
 
 }
+
+func TestCommitDoesNotAlias(t *testing.T) {
+	hc := NewHashCommiter()
+	r := hc.Setup()
+	buf := make([]byte, 2, 64)
+	copy(buf, "ab")
+	tail := buf[:4]
+	copy(tail[2:], "cd")
+
+	c := hc.Commit(buf, r)
+	assert.Equal(t, []byte("abcd"), tail)
+	assert.True(t, hc.Verify([]byte("ab"), r, c))
+	assert.False(t, hc.Verify([]byte("ab"), hc.Setup(), c))
+}
+
+func TestVerifyEquivocation(t *testing.T) {
+	hc := NewHashCommiter()
+	r := hc.Setup()
+	c := hc.Commit([]byte("value-one"), r)
+	assert.True(t, hc.Verify([]byte("value-one"), r, c))
+	// H("value" || "-one" || r) is the same hash
+	assert.False(t, hc.Verify([]byte("value"), append([]byte("-one"), r...), c))
+	assert.False(t, hc.Verify([]byte("value-one"), r[:NonceSize-1], c))
+}
@@ -0,0 +1,147 @@
+// Package hyrax implements the Hyrax commitment to multilinear polynomials,
+// transparent and with a verifier in O(√N): the 2ⁿ evaluations are arranged
+// in a matrix M whose rows are committed with vector Pedersen commitments,
+// and since f(z) = Lᵀ·M·R for L and R the multilinear Lagrange bases of the
+// two halves of z, an evaluation is proven by an inner product argument
+// ⟨Lᵀ·M, R⟩ = y on the combination Σ Lᵢ·cᵢ of the row commitments. The
+// commitments are hiding and the openings reveal nothing but f(z).
+// https://eprint.iacr.org/2017/1132
+package hyrax
+
+import (
+	"commitment/ipa_commitment"
+	"commitment/pedersen_commitment"
+	"commitment/primitives"
+	"fmt"
+	"github.com/drand/kyber/group/mod"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+// Hyrax commits to multilinear polynomials of NumVars variables, as a matrix
+// of 2^⌊n/2⌋ rows and 2^⌈n/2⌉ columns. The low variables index the columns.
+type Hyrax struct {
+	NumVars    int
+	rows, cols int
+	vc         *pedersen_commitment.VectorCommiter
+	ipa        *ipa_commitment.Setup
+}
+
+// Commitment holds the commitments to the rows of the matrix
+type Commitment struct {
+	Rows []*bn256.G1
+}
+
+// NewHyrax returns the commitment scheme of polynomials of n variables
+func NewHyrax(n int) (*Hyrax, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("the number of variables must not be negative, got %d", n)
+	}
+	cols := 1 << ((n + 1) / 2)
+	vc := pedersen_commitment.NewVectorCommiter(cols)
+	// the inner product argument runs on the bases of the row commitments
+	g := make([]primitives.G1, cols)
+	for i := range g {
+		g[i] = &primitives.BN254G1{P: vc.G[i]}
+	}
+	ipa := &ipa_commitment.Setup{
+		G: g,
+		H: &primitives.BN254G1{P: vc.H},
+		U: primitives.HashToBN254G1("hyrax U", nil),
+	}
+	return &Hyrax{NumVars: n, rows: 1 << (n / 2), cols: cols, vc: vc, ipa: ipa}, nil
+}
+
+// Commit commits to the rows of the evaluations of f, and returns the
+// commitment with the blinding factors of the rows needed to open it
+func (h *Hyrax) Commit(f *primitives.MultilinearPoly) (*Commitment, []*mod.Int, error) {
+	if f.NumVars != h.NumVars {
+		return nil, nil, fmt.Errorf("the commitment is for %d variables, got %d", h.NumVars, f.NumVars)
+	}
+	c := &Commitment{make([]*bn256.G1, h.rows)}
+	blinds := make([]*mod.Int, h.rows)
+	for i := range c.Rows {
+		r, err := primitives.BN254.Rand()
+		if err != nil {
+			return nil, nil, err
+		}
+		row, err := h.vc.Commit(f.Evaluations[i*h.cols:(i+1)*h.cols], r)
+		if err != nil {
+			return nil, nil, err
+		}
+		c.Rows[i], blinds[i] = row, r
+	}
+	return c, blinds, nil
+}
+
+// EvaluationProof generates the proof of f(z) = y
+func (h *Hyrax) EvaluationProof(f *primitives.MultilinearPoly, c *Commitment, blinds, z []*mod.Int, y *mod.Int, tr *primitives.Transcript) (*ipa_commitment.InnerProductProof, error) {
+	if f.NumVars != h.NumVars || len(z) != h.NumVars || len(blinds) != h.rows {
+		return nil, fmt.Errorf("the commitment is for %d variables and %d rows", h.NumVars, h.rows)
+	}
+	l, r := h.bases(z)
+	// t = Lᵀ·M, committed in Σ Lᵢ·cᵢ with the blinding factor Σ Lᵢ·rᵢ
+	t := make([]*mod.Int, h.cols)
+	for j := range t {
+		t[j] = primitives.BN254.NewElement(0)
+	}
+	blind := primitives.BN254.NewElement(0)
+	for i := 0; i < h.rows; i++ {
+		for j := range t {
+			t[j] = new(mod.Int).Add(t[j], new(mod.Int).Mul(l[i], f.Evaluations[i*h.cols+j])).(*mod.Int)
+		}
+		blind = new(mod.Int).Add(blind, new(mod.Int).Mul(l[i], blinds[i])).(*mod.Int)
+	}
+	if v := innerProduct(t, r); !v.Equal(y) {
+		return nil, fmt.Errorf("f(z) is %s, not %s", v.String(), y.String())
+	}
+	combined, err := pedersen_commitment.Combine(c.Rows, l)
+	if err != nil {
+		return nil, err
+	}
+	h.bind(tr, c, z)
+	return ipa_commitment.ProveInnerProduct(h.ipa, &primitives.BN254G1{P: combined}, t, r, blind, tr)
+}
+
+// Verify verifies the proof of f(z) = y for the commitment c, with O(√N)
+// group operations
+func (h *Hyrax) Verify(c *Commitment, proof *ipa_commitment.InnerProductProof, z []*mod.Int, y *mod.Int, tr *primitives.Transcript) bool {
+	if c == nil || len(c.Rows) != h.rows || len(z) != h.NumVars || y == nil || proof == nil {
+		return false
+	}
+	for _, zi := range z {
+		if zi == nil {
+			return false
+		}
+	}
+	l, r := h.bases(z)
+	combined, err := pedersen_commitment.Combine(c.Rows, l)
+	if err != nil {
+		return false
+	}
+	h.bind(tr, c, z)
+	return ipa_commitment.VerifyInnerProduct(h.ipa, &primitives.BN254G1{P: combined}, r, y, proof, tr)
+}
+
+// bases returns L and R, the Lagrange bases of the row and column variables
+// of z, such that f(z) = Lᵀ·M·R
+func (h *Hyrax) bases(z []*mod.Int) ([]*mod.Int, []*mod.Int) {
+	nCols := (h.NumVars + 1) / 2
+	r := primitives.EqPolynomial(primitives.BN254, z[:nCols]).Evaluations
+	l := primitives.EqPolynomial(primitives.BN254, z[nCols:]).Evaluations
+	return l, r
+}
+
+func (h *Hyrax) bind(tr *primitives.Transcript, c *Commitment, z []*mod.Int) {
+	for _, row := range c.Rows {
+		tr.AppendBytes("row", row.Marshal())
+	}
+	tr.AppendScalars("z", z)
+}
+
+func innerProduct(a, b []*mod.Int) *mod.Int {
+	r := primitives.BN254.NewElement(0)
+	for i := range a {
+		r = new(mod.Int).Add(r, new(mod.Int).Mul(a[i], b[i])).(*mod.Int)
+	}
+	return r
+}
@@ -0,0 +1,66 @@
+package hyrax
+
+import (
+	"commitment/primitives"
+	"github.com/drand/kyber/group/mod"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSimpleFlow(t *testing.T) {
+	// square and non square matrices
+	for _, n := range []int{4, 5} {
+		h, err := NewHyrax(n)
+		assert.Nil(t, err)
+		evals, err := primitives.BN254.RandVector(1 << n)
+		assert.Nil(t, err)
+		f, err := primitives.NewMultilinearPoly(evals)
+		assert.Nil(t, err)
+		c, blinds, err := h.Commit(f)
+		assert.Nil(t, err)
+		assert.Equal(t, 1<<(n/2), len(c.Rows))
+
+		z, err := primitives.BN254.RandVector(n)
+		assert.Nil(t, err)
+		y, _ := f.Evaluate(z)
+		proof, err := h.EvaluationProof(f, c, blinds, z, y, primitives.NewTranscript("test"))
+		assert.Nil(t, err)
+		assert.True(t, h.Verify(c, proof, z, y, primitives.NewTranscript("test")), n)
+
+		assert.False(t, h.Verify(c, proof, z, z[0], primitives.NewTranscript("test")), n)
+		assert.False(t, h.Verify(c, proof, append([]*mod.Int{z[1], z[0]}, z[2:]...), y, primitives.NewTranscript("test")), n)
+		c.Rows[0], c.Rows[1] = c.Rows[1], c.Rows[0]
+		assert.False(t, h.Verify(c, proof, z, y, primitives.NewTranscript("test")), n)
+
+		_, err = h.EvaluationProof(f, c, blinds, z, z[0], primitives.NewTranscript("test"))
+		assert.NotNil(t, err)
+	}
+}
+
+func TestVerifyMalformed(t *testing.T) {
+	h, err := NewHyrax(4)
+	assert.Nil(t, err)
+	evals, err := primitives.BN254.RandVector(16)
+	assert.Nil(t, err)
+	f, err := primitives.NewMultilinearPoly(evals)
+	assert.Nil(t, err)
+	c, blinds, err := h.Commit(f)
+	assert.Nil(t, err)
+	z, err := primitives.BN254.RandVector(4)
+	assert.Nil(t, err)
+	y, _ := f.Evaluate(z)
+	proof, err := h.EvaluationProof(f, c, blinds, z, y, primitives.NewTranscript("test"))
+	assert.Nil(t, err)
+
+	assert.False(t, h.Verify(c, nil, z, y, primitives.NewTranscript("test")))
+	assert.False(t, h.Verify(nil, proof, z, y, primitives.NewTranscript("test")))
+	assert.False(t, h.Verify(&Commitment{c.Rows[:2]}, proof, z, y, primitives.NewTranscript("test")))
+	rows := append([]*bn256.G1{}, c.Rows...)
+	rows[1] = nil
+	assert.False(t, h.Verify(&Commitment{rows}, proof, z, y, primitives.NewTranscript("test")))
+	bad := *proof
+	bad.L = append([]primitives.G1{}, proof.L[:1]...)
+	assert.False(t, h.Verify(c, &bad, z, y, primitives.NewTranscript("test")))
+	assert.True(t, h.Verify(c, proof, z, y, primitives.NewTranscript("test")))
+}
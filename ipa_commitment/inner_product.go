@@ -0,0 +1,238 @@
+package ipa_commitment
+
+import (
+	"commitment/primitives"
+	"fmt"
+	"github.com/drand/kyber/group/mod"
+	"math/big"
+)
+
+// InnerProductProof proves the knowledge of a such that c = ⟨a, G⟩ + r·H and
+// ⟨a, b⟩ = y for a public vector b. Each round halves the vectors, so the
+// proof has 2·log₂(n) points. The rounds are blinded by H and the folded a
+// and r are not revealed but proven by a final Schnorr step, so the proof is
+// zero knowledge as in Halo.
+type InnerProductProof struct {
+	L, R   []primitives.G1
+	D      primitives.G1 // d·(G' + b'·U') + s·H for random d and s
+	Z1, Z2 *mod.Int      // e·a' + d and e·r' + s, a' and r' folded
+}
+
+// ProveInnerProduct proves ⟨a, b⟩ = y for the commitment c = ⟨a, G⟩ + r·H.
+// a and b are padded with zeros to the size of the setup.
+func ProveInnerProduct(s *Setup, c primitives.G1, a, b []*mod.Int, r *mod.Int, tr *primitives.Transcript) (*InnerProductProof, error) {
+	if len(a) > len(s.G) || len(b) > len(s.G) {
+		return nil, fmt.Errorf("the setup supports vectors of %d elements, got %d and %d", len(s.G), len(a), len(b))
+	}
+	a, b = pad(a, len(s.G)), pad(b, len(s.G))
+	g := append([]primitives.G1{}, s.G...)
+	w := s.challengeU(tr, c, innerProduct(a, b))
+	u := s.U.ScalarMult(&w.V)
+
+	proof := &InnerProductProof{}
+	for n := len(g) / 2; n >= 1; n /= 2 {
+		aL, aR, bL, bR, gL, gR := a[:n], a[n:], b[:n], b[n:], g[:n], g[n:]
+		lBlind, err := primitives.BN254.Rand()
+		if err != nil {
+			return nil, err
+		}
+		rBlind, err := primitives.BN254.Rand()
+		if err != nil {
+			return nil, err
+		}
+		// L = ⟨aL, GR⟩ + ⟨aL, bR⟩·U + l·H,  R = ⟨aR, GL⟩ + ⟨aR, bL⟩·U + r·H
+		l := msm(gR, aL).Add(u.ScalarMult(&innerProduct(aL, bR).V)).Add(s.H.ScalarMult(&lBlind.V))
+		rr := msm(gL, aR).Add(u.ScalarMult(&innerProduct(aR, bL).V)).Add(s.H.ScalarMult(&rBlind.V))
+		proof.L, proof.R = append(proof.L, l), append(proof.R, rr)
+		tr.AppendG1("L", l)
+		tr.AppendG1("R", rr)
+		x := tr.ChallengeScalar("x", primitives.BN254)
+		xInv := new(mod.Int).Inv(x).(*mod.Int)
+
+		// a' = x·aL + x⁻¹·aR,  b' = x⁻¹·bL + x·bR,  G' = x⁻¹·GL + x·GR
+		a2, b2, g2 := make([]*mod.Int, n), make([]*mod.Int, n), make([]primitives.G1, n)
+		for i := 0; i < n; i++ {
+			a2[i] = new(mod.Int).Add(new(mod.Int).Mul(x, aL[i]), new(mod.Int).Mul(xInv, aR[i])).(*mod.Int)
+			b2[i] = new(mod.Int).Add(new(mod.Int).Mul(xInv, bL[i]), new(mod.Int).Mul(x, bR[i])).(*mod.Int)
+			g2[i] = gL[i].ScalarMult(&xInv.V).Add(gR[i].ScalarMult(&x.V))
+		}
+		a, b, g = a2, b2, g2
+		// r' = r + x²·l + x⁻²·r
+		x2 := new(mod.Int).Mul(x, x)
+		r = new(mod.Int).Add(r, new(mod.Int).Add(new(mod.Int).Mul(x2, lBlind), new(mod.Int).Div(rBlind, x2))).(*mod.Int)
+	}
+
+	// prove the knowledge of a' and r' with c' = a'·(G' + b'·U') + r'·H
+	d, err := primitives.BN254.Rand()
+	if err != nil {
+		return nil, err
+	}
+	sBlind, err := primitives.BN254.Rand()
+	if err != nil {
+		return nil, err
+	}
+	base := g[0].Add(u.ScalarMult(&b[0].V))
+	proof.D = base.ScalarMult(&d.V).Add(s.H.ScalarMult(&sBlind.V))
+	tr.AppendG1("D", proof.D)
+	e := tr.ChallengeScalar("e", primitives.BN254)
+	proof.Z1 = new(mod.Int).Add(new(mod.Int).Mul(e, a[0]), d).(*mod.Int)
+	proof.Z2 = new(mod.Int).Add(new(mod.Int).Mul(e, r), sBlind).(*mod.Int)
+	return proof, nil
+}
+
+// VerifyInnerProduct verifies the proof of ⟨a, b⟩ = y for the commitment c,
+// in time linear in the size of the setup
+func VerifyInnerProduct(s *Setup, c primitives.G1, b []*mod.Int, y *mod.Int, proof *InnerProductProof, tr *primitives.Transcript) bool {
+	if len(b) > len(s.G) {
+		return false
+	}
+	acc := NewAccumulator(s)
+	w, xs, e, ok := acc.challenges(c, y, proof, tr)
+	if !ok {
+		return false
+	}
+	sv := sVector(xs)
+	// b folded to a single element: ⟨s, b⟩
+	acc.add(c, y, proof, w, e, xs, sv, innerProduct(sv, pad(b, len(s.G))))
+	return acc.Check()
+}
+
+// Accumulator defers the linear part of the verification of many inner
+// product proofs, the multi-scalar multiplication by G, to a single check.
+// Each proof is weighted by a random ρ, so a single invalid proof makes the
+// check fail except with negligible probability.
+type Accumulator struct {
+	s     *Setup
+	g     []*mod.Int // scalars of G
+	ps    []primitives.G1
+	ks    []*big.Int
+	uK    *mod.Int // scalar of U
+	hK    *mod.Int // scalar of H
+	valid bool
+}
+
+// NewAccumulator returns an empty accumulator
+func NewAccumulator(s *Setup) *Accumulator {
+	g := make([]*mod.Int, len(s.G))
+	for i := range g {
+		g[i] = primitives.BN254.NewElement(0)
+	}
+	return &Accumulator{
+		s:     s,
+		g:     g,
+		uK:    primitives.BN254.NewElement(0),
+		hK:    primitives.BN254.NewElement(0),
+		valid: true,
+	}
+}
+
+// challenges replays the transcript of the proof and returns the challenge
+// of U, the round challenges and the challenge of the final step
+func (acc *Accumulator) challenges(c primitives.G1, y *mod.Int, proof *InnerProductProof, tr *primitives.Transcript) (*mod.Int, []*mod.Int, *mod.Int, bool) {
+	rounds := 0
+	for n := len(acc.s.G); n > 1; n /= 2 {
+		rounds++
+	}
+	if proof == nil || len(proof.L) != rounds || len(proof.R) != rounds || proof.D == nil || proof.Z1 == nil || proof.Z2 == nil {
+		return nil, nil, nil, false
+	}
+	w := acc.s.challengeU(tr, c, y)
+	xs := make([]*mod.Int, rounds)
+	for j := range xs {
+		tr.AppendG1("L", proof.L[j])
+		tr.AppendG1("R", proof.R[j])
+		xs[j] = tr.ChallengeScalar("x", primitives.BN254)
+	}
+	tr.AppendG1("D", proof.D)
+	e := tr.ChallengeScalar("e", primitives.BN254)
+	return w, xs, e, true
+}
+
+// add accumulates the check
+//
+//	e·(c + y·U' + Σ (xⱼ²Lⱼ + xⱼ⁻²Rⱼ)) + D == z₁·⟨s, G⟩ + z₁·bFinal·U' + z₂·H
+//
+// with U' = w·U and bFinal = ⟨s, b⟩
+func (acc *Accumulator) add(c primitives.G1, y *mod.Int, proof *InnerProductProof, w, e *mod.Int, xs, sv []*mod.Int, bFinal *mod.Int) {
+	rho, err := primitives.BN254.Rand()
+	if err != nil {
+		acc.valid = false
+		return
+	}
+	rhoE := new(mod.Int).Mul(rho, e).(*mod.Int)
+	acc.ps = append(acc.ps, c, proof.D)
+	acc.ks = append(acc.ks, &rhoE.V, &rho.V)
+	for j, x := range xs {
+		x2 := new(mod.Int).Mul(x, x).(*mod.Int)
+		x2Inv := new(mod.Int).Inv(x2).(*mod.Int)
+		acc.ps = append(acc.ps, proof.L[j], proof.R[j])
+		acc.ks = append(acc.ks, &new(mod.Int).Mul(rhoE, x2).(*mod.Int).V, &new(mod.Int).Mul(rhoE, x2Inv).(*mod.Int).V)
+	}
+	// ρ·w·(e·y - z₁·bFinal) on U, -ρ·z₂ on H, -ρ·z₁·sᵢ on Gᵢ
+	uK := new(mod.Int).Sub(new(mod.Int).Mul(e, y), new(mod.Int).Mul(proof.Z1, bFinal))
+	acc.uK = new(mod.Int).Add(acc.uK, new(mod.Int).Mul(rho, new(mod.Int).Mul(w, uK))).(*mod.Int)
+	acc.hK = new(mod.Int).Sub(acc.hK, new(mod.Int).Mul(rho, proof.Z2)).(*mod.Int)
+	rhoZ := new(mod.Int).Mul(rho, proof.Z1)
+	for i := range acc.g {
+		acc.g[i] = new(mod.Int).Sub(acc.g[i], new(mod.Int).Mul(rhoZ, sv[i])).(*mod.Int)
+	}
+}
+
+// Check verifies all the accumulated proofs with one multi-scalar
+// multiplication
+func (acc *Accumulator) Check() bool {
+	if !acc.valid {
+		return false
+	}
+	ps := append(append([]primitives.G1{}, acc.ps...), acc.s.U, acc.s.H)
+	ks := append(append([]*big.Int{}, acc.ks...), &acc.uK.V, &acc.hK.V)
+	ps = append(ps, acc.s.G...)
+	for _, k := range acc.g {
+		ks = append(ks, &k.V)
+	}
+	return primitives.MultiScalarMultG1(primitives.CurveBN254, ps, ks).Equal(primitives.G1Zero(primitives.CurveBN254))
+}
+
+// sVector returns s with sᵢ = Πⱼ xⱼ^(±1), xⱼ when the bit of round j of i
+// is set, so that the folded generator is ⟨s, G⟩. Round j splits the
+// vectors on the bit k-1-j of the index.
+func sVector(xs []*mod.Int) []*mod.Int {
+	k := len(xs)
+	xInvs := make([]*mod.Int, k)
+	for j, x := range xs {
+		xInvs[j] = new(mod.Int).Inv(x).(*mod.Int)
+	}
+	s := make([]*mod.Int, 1<<k)
+	for i := range s {
+		s[i] = primitives.BN254.NewElement(1)
+		for j := 0; j < k; j++ {
+			if i>>(k-1-j)&1 == 1 {
+				s[i] = new(mod.Int).Mul(s[i], xs[j]).(*mod.Int)
+			} else {
+				s[i] = new(mod.Int).Mul(s[i], xInvs[j]).(*mod.Int)
+			}
+		}
+	}
+	return s
+}
+
+func innerProduct(a, b []*mod.Int) *mod.Int {
+	r := primitives.BN254.NewElement(0)
+	for i := range a {
+		r = new(mod.Int).Add(r, new(mod.Int).Mul(a[i], b[i])).(*mod.Int)
+	}
+	return r
+}
+
+func msm(ps []primitives.G1, ks []*mod.Int) primitives.G1 {
+	return primitives.MultiScalarMultG1(primitives.CurveBN254, ps, primitives.Scalars(ks))
+}
+
+func pad(a []*mod.Int, n int) []*mod.Int {
+	p := make([]*mod.Int, n)
+	copy(p, a)
+	for i := len(a); i < n; i++ {
+		p[i] = primitives.BN254.NewElement(0)
+	}
+	return p
+}
@@ -0,0 +1,72 @@
+package ipa_commitment
+
+import (
+	"commitment/primitives"
+	"github.com/drand/kyber/group/mod"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestInnerProduct(t *testing.T) {
+	s, err := NewSetup(8)
+	assert.Nil(t, err)
+	a, err := primitives.BN254.RandVector(8)
+	assert.Nil(t, err)
+	b, err := primitives.BN254.RandVector(6)
+	assert.Nil(t, err)
+	r, _ := primitives.BN254.Rand()
+	c, err := CommitVector(s, a, r)
+	assert.Nil(t, err)
+	y := innerProduct(a[:6], b)
+
+	proof, err := ProveInnerProduct(s, c, a, b, r, primitives.NewTranscript("test"))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(proof.L))
+	assert.True(t, VerifyInnerProduct(s, c, b, y, proof, primitives.NewTranscript("test")))
+
+	assert.False(t, VerifyInnerProduct(s, c, b, a[0], proof, primitives.NewTranscript("test")))
+	assert.False(t, VerifyInnerProduct(s, c, a[:6], y, proof, primitives.NewTranscript("test")))
+	assert.False(t, VerifyInnerProduct(s, c, b, y, proof, primitives.NewTranscript("other")))
+	z2 := proof.Z2
+	proof.Z2 = a[0]
+	assert.False(t, VerifyInnerProduct(s, c, b, y, proof, primitives.NewTranscript("test")))
+	proof.Z2 = z2
+	proof.D = nil
+	assert.False(t, VerifyInnerProduct(s, c, b, y, proof, primitives.NewTranscript("test")))
+	assert.False(t, VerifyInnerProduct(s, c, b, y, nil, primitives.NewTranscript("test")))
+}
+
+func TestInnerProduct_Blinded(t *testing.T) {
+	s, err := NewSetup(4)
+	assert.Nil(t, err)
+	a, err := primitives.BN254.RandVector(4)
+	assert.Nil(t, err)
+	b, err := primitives.BN254.RandVector(4)
+	assert.Nil(t, err)
+	r, _ := primitives.BN254.Rand()
+	c, _ := CommitVector(s, a, r)
+	y := innerProduct(a, b)
+
+	// the proofs of the same statement are randomized and do not reveal r
+	p1, err := ProveInnerProduct(s, c, a, b, r, primitives.NewTranscript("test"))
+	assert.Nil(t, err)
+	p2, err := ProveInnerProduct(s, c, a, b, r, primitives.NewTranscript("test"))
+	assert.Nil(t, err)
+	for j := range p1.L {
+		assert.False(t, p1.L[j].Equal(p2.L[j]))
+		assert.False(t, p1.R[j].Equal(p2.R[j]))
+	}
+	assert.False(t, p1.Z2.Equal(r))
+	assert.False(t, p1.Z2.Equal(p2.Z2))
+	assert.True(t, VerifyInnerProduct(s, c, b, y, p1, primitives.NewTranscript("test")))
+	assert.True(t, VerifyInnerProduct(s, c, b, y, p2, primitives.NewTranscript("test")))
+}
+
+func TestSVector(t *testing.T) {
+	xs, err := primitives.BN254.RandVector(3)
+	assert.Nil(t, err)
+	s := sVector(xs)
+	// s₅ = s₁₀₁ = x₀·x₁⁻¹·x₂
+	e := new(mod.Int).Div(new(mod.Int).Mul(xs[0], xs[2]), xs[1])
+	assert.True(t, s[5].Equal(e))
+}
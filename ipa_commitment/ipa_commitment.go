@@ -0,0 +1,128 @@
+// Package ipa_commitment implements a transparent polynomial commitment on
+// the inner product argument of Bulletproofs, as used by Halo: the bases are
+// derived by hashing to BN254 𝔾₁, so there is no trusted setup. A polynomial
+// p is committed as ⟨p, G⟩ and p(z) = ⟨p, (1, z, z², ...)⟩ is proven with
+// 2·log₂(n) points. https://eprint.iacr.org/2019/1021
+package ipa_commitment
+
+import (
+	"commitment/primitives"
+	"encoding/binary"
+	"fmt"
+	"github.com/drand/kyber/group/mod"
+)
+
+// Setup holds the public bases, of unknown discrete logarithms to each other
+type Setup struct {
+	G []primitives.G1 // commitment to the coefficients
+	H primitives.G1   // blinding
+	U primitives.G1   // inner product
+}
+
+// NewSetup derives the bases for polynomials of up to n coefficients, n a
+// power of two
+func NewSetup(n int) (*Setup, error) {
+	if n < 1 || n&(n-1) != 0 {
+		return nil, fmt.Errorf("the size of the setup must be a power of two, got %d", n)
+	}
+	g := make([]primitives.G1, n)
+	for i := range g {
+		var b [8]byte
+		binary.BigEndian.PutUint64(b[:], uint64(i))
+		g[i] = primitives.HashToBN254G1("ipa_commitment G", b[:])
+	}
+	return &Setup{
+		G: g,
+		H: primitives.HashToBN254G1("ipa_commitment H", nil),
+		U: primitives.HashToBN254G1("ipa_commitment U", nil),
+	}, nil
+}
+
+// challengeU binds the statement to the transcript and returns the challenge
+// w of the inner product base U' = w·U
+func (s *Setup) challengeU(tr *primitives.Transcript, c primitives.G1, y *mod.Int) *mod.Int {
+	tr.AppendG1("C", c)
+	tr.AppendScalar("y", y)
+	return tr.ChallengeScalar("w", primitives.BN254)
+}
+
+// CommitVector returns the Pedersen vector commitment ⟨a, G⟩ + r·H
+func CommitVector(s *Setup, a []*mod.Int, r *mod.Int) (primitives.G1, error) {
+	if len(a) > len(s.G) {
+		return nil, fmt.Errorf("the setup supports vectors of %d elements, got %d", len(s.G), len(a))
+	}
+	return msm(s.G[:len(a)], a).Add(s.H.ScalarMult(&r.V)), nil
+}
+
+// Commit generates the commitment ⟨p, G⟩ to the polynomial p(x)
+func Commit(s *Setup, p *primitives.Polynomial) (primitives.G1, error) {
+	return CommitVector(s, p.Coefficient, primitives.BN254.NewElement(0))
+}
+
+// EvaluationProof generates the proof of p(z) = y
+func EvaluationProof(s *Setup, p *primitives.Polynomial, z, y *mod.Int, tr *primitives.Transcript) (*InnerProductProof, error) {
+	if !p.Eval(z).Equal(y) {
+		return nil, fmt.Errorf("p(z) is %s, not %s", p.Eval(z).String(), y.String())
+	}
+	c, err := Commit(s, p)
+	if err != nil {
+		return nil, err
+	}
+	return ProveInnerProduct(s, c, p.Coefficient, powers(z, len(s.G)), primitives.BN254.NewElement(0), tr)
+}
+
+// Verify verifies the proof of p(z) = y for the commitment c, in time linear
+// in the size of the setup
+func Verify(s *Setup, c primitives.G1, proof *InnerProductProof, z, y *mod.Int, tr *primitives.Transcript) bool {
+	acc := NewAccumulator(s)
+	acc.AddEvaluation(c, proof, z, y, tr)
+	return acc.Check()
+}
+
+// AddEvaluation accumulates the proof of p(z) = y for the commitment c, with
+// O(log n) group operations. The linear part is deferred to Check.
+func (acc *Accumulator) AddEvaluation(c primitives.G1, proof *InnerProductProof, z, y *mod.Int, tr *primitives.Transcript) {
+	w, xs, e, ok := acc.challenges(c, y, proof, tr)
+	if !ok {
+		acc.valid = false
+		return
+	}
+	// for b = (1, z, z², ...) the folded b is Πⱼ (xⱼ⁻¹ + xⱼ·z^(2^(k-1-j)))
+	bFinal := primitives.BN254.NewElement(1)
+	zPow := z
+	for j := len(xs) - 1; j >= 0; j-- {
+		xInv := new(mod.Int).Inv(xs[j])
+		bFinal = new(mod.Int).Mul(bFinal, new(mod.Int).Add(xInv, new(mod.Int).Mul(xs[j], zPow))).(*mod.Int)
+		zPow = new(mod.Int).Mul(zPow, zPow).(*mod.Int)
+	}
+	acc.add(c, y, proof, w, e, xs, sVector(xs), bFinal)
+}
+
+// Opening is the claim that the polynomial committed in C evaluates to Y at
+// Z, together with its evaluation proof and transcript
+type Opening struct {
+	C          primitives.G1
+	Proof      *InnerProductProof
+	Z, Y       *mod.Int
+	Transcript *primitives.Transcript
+}
+
+// BatchVerify verifies many openings with a single multi-scalar
+// multiplication over the bases
+func BatchVerify(s *Setup, openings []*Opening) bool {
+	acc := NewAccumulator(s)
+	for _, o := range openings {
+		acc.AddEvaluation(o.C, o.Proof, o.Z, o.Y, o.Transcript)
+	}
+	return acc.Check()
+}
+
+// powers returns 1, z, z², ..., zⁿ⁻¹
+func powers(z *mod.Int, n int) []*mod.Int {
+	ps := make([]*mod.Int, n)
+	ps[0] = primitives.BN254.NewElement(1)
+	for i := 1; i < n; i++ {
+		ps[i] = new(mod.Int).Mul(ps[i-1], z).(*mod.Int)
+	}
+	return ps
+}
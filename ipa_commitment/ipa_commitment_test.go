@@ -0,0 +1,65 @@
+package ipa_commitment
+
+import (
+	"commitment/primitives"
+	"github.com/drand/kyber/group/mod"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSimpleFlow(t *testing.T) {
+	s, err := NewSetup(16)
+	assert.Nil(t, err)
+	// p(x) = x^3 + x + 5
+	p := new(primitives.Polynomial).Init([]*mod.Int{
+		mod.NewInt64(5, primitives.Q),
+		mod.NewInt64(1, primitives.Q),
+		mod.NewInt64(0, primitives.Q),
+		mod.NewInt64(1, primitives.Q),
+	})
+	c, err := Commit(s, p)
+	assert.Nil(t, err)
+
+	z := mod.NewInt64(3, primitives.Q)
+	y := mod.NewInt64(35, primitives.Q)
+	proof, err := EvaluationProof(s, p, z, y, primitives.NewTranscript("test"))
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(proof.L))
+	assert.True(t, Verify(s, c, proof, z, y, primitives.NewTranscript("test")))
+
+	assert.False(t, Verify(s, c, proof, z, mod.NewInt64(36, primitives.Q), primitives.NewTranscript("test")))
+	assert.False(t, Verify(s, c, proof, mod.NewInt64(4, primitives.Q), y, primitives.NewTranscript("test")))
+	assert.False(t, Verify(s, c.Add(c), proof, z, y, primitives.NewTranscript("test")))
+
+	_, err = EvaluationProof(s, p, z, mod.NewInt64(36, primitives.Q), primitives.NewTranscript("test"))
+	assert.NotNil(t, err)
+	_, err = NewSetup(12)
+	assert.NotNil(t, err)
+}
+
+func TestBatchVerify(t *testing.T) {
+	s, err := NewSetup(8)
+	assert.Nil(t, err)
+	openings := make([]*Opening, 4)
+	for i := range openings {
+		coeffs, err := primitives.BN254.RandVector(8)
+		assert.Nil(t, err)
+		p := new(primitives.Polynomial).Init(coeffs)
+		c, _ := Commit(s, p)
+		z, _ := primitives.BN254.Rand()
+		proof, err := EvaluationProof(s, p, z, p.Eval(z), primitives.NewTranscript("test"))
+		assert.Nil(t, err)
+		openings[i] = &Opening{c, proof, z, p.Eval(z), nil}
+	}
+	reset := func() {
+		for _, o := range openings {
+			o.Transcript = primitives.NewTranscript("test")
+		}
+	}
+	reset()
+	assert.True(t, BatchVerify(s, openings))
+
+	openings[2].Y = openings[1].Y
+	reset()
+	assert.False(t, BatchVerify(s, openings))
+}
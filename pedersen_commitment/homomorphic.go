@@ -0,0 +1,54 @@
+package pedersen_commitment
+
+import (
+	"bytes"
+	"github.com/drand/kyber/group/mod"
+	"github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+// Commiter commits to single field elements: c = m·G + r·H, with the first
+// base and the blinding base of the vector commiter. The commitments are
+// additively homomorphic.
+type Commiter struct {
+	G, H *bn256.G1
+}
+
+// NewCommiter returns a commiter of field elements
+func NewCommiter() *Commiter {
+	vc := NewVectorCommiter(1)
+	return &Commiter{G: vc.G[0], H: vc.H}
+}
+
+// Commit returns m·G + r·H
+func (pc *Commiter) Commit(m, r *mod.Int) *bn256.G1 {
+	c := new(bn256.G1).ScalarMult(pc.G, &m.V)
+	return c.Add(c, new(bn256.G1).ScalarMult(pc.H, &r.V))
+}
+
+// Verify checks that c opens to m with the blinding factor r
+func (pc *Commiter) Verify(c *bn256.G1, m, r *mod.Int) bool {
+	return bytes.Equal(pc.Commit(m, r).Marshal(), c.Marshal())
+}
+
+// AddConstant returns c + k·G, the commitment to m + k with the same
+// blinding factor
+func (pc *Commiter) AddConstant(c *bn256.G1, k *mod.Int) *bn256.G1 {
+	return new(bn256.G1).Add(c, new(bn256.G1).ScalarMult(pc.G, &k.V))
+}
+
+// Add returns a + b, the commitment to m₁ + m₂ with the blinding factor
+// r₁ + r₂
+func Add(a, b *bn256.G1) *bn256.G1 {
+	return new(bn256.G1).Add(a, b)
+}
+
+// Sub returns a - b, the commitment to m₁ - m₂ with the blinding factor
+// r₁ - r₂
+func Sub(a, b *bn256.G1) *bn256.G1 {
+	return new(bn256.G1).Add(a, new(bn256.G1).Neg(b))
+}
+
+// ScalarMul returns k·c, the commitment to k·m with the blinding factor k·r
+func ScalarMul(c *bn256.G1, k *mod.Int) *bn256.G1 {
+	return new(bn256.G1).ScalarMult(c, &k.V)
+}
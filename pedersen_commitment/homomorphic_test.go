@@ -0,0 +1,26 @@
+package pedersen_commitment
+
+import (
+	"commitment/primitives"
+	"github.com/drand/kyber/group/mod"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCommiter(t *testing.T) {
+	pc := NewCommiter()
+	m1, m2 := primitives.BN254.NewElement(10), primitives.BN254.NewElement(3)
+	r1, _ := primitives.BN254.Rand()
+	r2, _ := primitives.BN254.Rand()
+	c1, c2 := pc.Commit(m1, r1), pc.Commit(m2, r2)
+	assert.True(t, pc.Verify(c1, m1, r1))
+	assert.False(t, pc.Verify(c1, m2, r1))
+
+	assert.True(t, pc.Verify(Add(c1, c2), primitives.BN254.NewElement(13), new(mod.Int).Add(r1, r2).(*mod.Int)))
+	assert.True(t, pc.Verify(Sub(c1, c2), primitives.BN254.NewElement(7), new(mod.Int).Sub(r1, r2).(*mod.Int)))
+	assert.True(t, pc.Verify(Sub(c2, c1), primitives.BN254.NewElement(-7), new(mod.Int).Sub(r2, r1).(*mod.Int)))
+	assert.True(t, pc.Verify(ScalarMul(c1, m2), primitives.BN254.NewElement(30), new(mod.Int).Mul(r1, m2).(*mod.Int)))
+	assert.True(t, pc.Verify(pc.AddConstant(c1, m2), primitives.BN254.NewElement(13), r1))
+	// the bases are left untouched
+	assert.True(t, pc.Verify(c1, m1, r1))
+}
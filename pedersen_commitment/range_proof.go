@@ -0,0 +1,163 @@
+package pedersen_commitment
+
+import (
+	"bytes"
+	"commitment/primitives"
+	"fmt"
+	"github.com/drand/kyber/group/mod"
+	"github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"math/big"
+)
+
+// RangeProof proves that a commitment opens to v ∈ [0, 2ⁿ), by committing
+// to the bits bᵢ of v in Cᵢ = bᵢ·G + rᵢ·H with Σ 2ⁱ·Cᵢ = C, and proving
+// that each Cᵢ opens to 0 or 1. The proof is zero knowledge and of size
+// O(n).
+type RangeProof struct {
+	Bits   []*bn256.G1
+	Proofs []*BitProof
+}
+
+// BitProof is the OR proof of Cramer, Damgård and Schoenmakers that
+// C = r·H or C - G = r·H: the Schnorr proof of the true branch is real, the
+// other one is simulated, and the challenges sum to the challenge e
+type BitProof struct {
+	A0, A1     *bn256.G1
+	E0, Z0, Z1 *mod.Int
+}
+
+// ProveRange proves that the commitment to v with the blinding factor r
+// opens to a value in [0, 2ⁿ)
+func (pc *Commiter) ProveRange(v, r *mod.Int, n int, tr *primitives.Transcript) (*RangeProof, error) {
+	if n <= 0 || n >= primitives.BN254.Modulus().BitLen() {
+		return nil, fmt.Errorf("the range must have between 1 and %d bits, got %d", primitives.BN254.Modulus().BitLen()-1, n)
+	}
+	if v.V.BitLen() > n {
+		return nil, fmt.Errorf("%s is not in [0, 2^%d)", v.V.String(), n)
+	}
+	pc.bindRange(tr, pc.Commit(v, r), n)
+	proof := &RangeProof{make([]*bn256.G1, n), make([]*BitProof, n)}
+	// rₙ₋₁ = (r - Σ_{i<n-1} 2ⁱ·rᵢ)/2ⁿ⁻¹ so that Σ 2ⁱ·rᵢ = r
+	rest := new(mod.Int).Set(r).(*mod.Int)
+	rs := make([]*mod.Int, n)
+	for i := 0; i < n-1; i++ {
+		ri, err := primitives.BN254.Rand()
+		if err != nil {
+			return nil, err
+		}
+		rs[i] = ri
+		rest = new(mod.Int).Sub(rest, new(mod.Int).Mul(ri, pow2(i))).(*mod.Int)
+	}
+	rs[n-1] = new(mod.Int).Div(rest, pow2(n-1)).(*mod.Int)
+	for i := range rs {
+		b := primitives.BN254.NewElement(int64(v.V.Bit(i)))
+		proof.Bits[i] = pc.Commit(b, rs[i])
+		tr.AppendBytes("bit", proof.Bits[i].Marshal())
+	}
+	for i := range rs {
+		bp, err := pc.proveBit(proof.Bits[i], v.V.Bit(i), rs[i], tr)
+		if err != nil {
+			return nil, err
+		}
+		proof.Proofs[i] = bp
+	}
+	return proof, nil
+}
+
+// VerifyRange verifies the proof that c opens to a value in [0, 2ⁿ)
+func (pc *Commiter) VerifyRange(c *bn256.G1, n int, proof *RangeProof, tr *primitives.Transcript) bool {
+	if c == nil || proof == nil || n <= 0 || n >= primitives.BN254.Modulus().BitLen() || len(proof.Bits) != n || len(proof.Proofs) != n {
+		return false
+	}
+	for i := range proof.Bits {
+		if proof.Bits[i] == nil || !proof.Proofs[i].wellFormed() {
+			return false
+		}
+	}
+	pc.bindRange(tr, c, n)
+	sum := new(bn256.G1).ScalarBaseMult(new(big.Int))
+	for i, ci := range proof.Bits {
+		sum.Add(sum, ScalarMul(ci, pow2(i)))
+		tr.AppendBytes("bit", ci.Marshal())
+	}
+	if !bytes.Equal(sum.Marshal(), c.Marshal()) {
+		return false
+	}
+	for i, ci := range proof.Bits {
+		if !pc.verifyBit(ci, proof.Proofs[i], tr) {
+			return false
+		}
+	}
+	return true
+}
+
+func (pc *Commiter) bindRange(tr *primitives.Transcript, c *bn256.G1, n int) {
+	tr.AppendBytes("commitment", c.Marshal())
+	tr.AppendScalar("bits", primitives.BN254.NewElement(int64(n)))
+}
+
+// proveBit proves that c = b·G + r·H opens to 0 or 1, with Y₀ = c and
+// Y₁ = c - G: the prover knows r with Y_b = r·H
+func (pc *Commiter) proveBit(c *bn256.G1, b uint, r *mod.Int, tr *primitives.Transcript) (*BitProof, error) {
+	ys := [2]*bn256.G1{c, Sub(c, pc.G)}
+	w, err := primitives.BN254.Rand()
+	if err != nil {
+		return nil, err
+	}
+	// the other branch is simulated: A = z·H - e·Y for random e and z
+	eSim, err := primitives.BN254.Rand()
+	if err != nil {
+		return nil, err
+	}
+	zSim, err := primitives.BN254.Rand()
+	if err != nil {
+		return nil, err
+	}
+	var as [2]*bn256.G1
+	as[b] = new(bn256.G1).ScalarMult(pc.H, &w.V)
+	as[1-b] = Sub(new(bn256.G1).ScalarMult(pc.H, &zSim.V), ScalarMul(ys[1-b], eSim))
+
+	e := bitChallenge(tr, as)
+	eb := new(mod.Int).Sub(e, eSim).(*mod.Int)
+	zb := new(mod.Int).Add(w, new(mod.Int).Mul(eb, r)).(*mod.Int)
+	if b == 0 {
+		return &BitProof{as[0], as[1], eb, zb, zSim}, nil
+	}
+	return &BitProof{as[0], as[1], eSim, zSim, zb}, nil
+}
+
+// verifyBit checks zⱼ·H = Aⱼ + eⱼ·Yⱼ for both branches, with e₀ + e₁ = e
+func (pc *Commiter) verifyBit(c *bn256.G1, proof *BitProof, tr *primitives.Transcript) bool {
+	if c == nil || !proof.wellFormed() {
+		return false
+	}
+	ys := [2]*bn256.G1{c, Sub(c, pc.G)}
+	e := bitChallenge(tr, [2]*bn256.G1{proof.A0, proof.A1})
+	es := [2]*mod.Int{proof.E0, new(mod.Int).Sub(e, proof.E0).(*mod.Int)}
+	zs := [2]*mod.Int{proof.Z0, proof.Z1}
+	as := [2]*bn256.G1{proof.A0, proof.A1}
+	for j := range ys {
+		lhs := new(bn256.G1).ScalarMult(pc.H, &zs[j].V)
+		rhs := Add(as[j], ScalarMul(ys[j], es[j]))
+		if !bytes.Equal(lhs.Marshal(), rhs.Marshal()) {
+			return false
+		}
+	}
+	return true
+}
+
+// wellFormed reports whether the proof has all its fields
+func (p *BitProof) wellFormed() bool {
+	return p != nil && p.A0 != nil && p.A1 != nil && p.E0 != nil && p.Z0 != nil && p.Z1 != nil
+}
+
+func bitChallenge(tr *primitives.Transcript, as [2]*bn256.G1) *mod.Int {
+	tr.AppendBytes("A0", as[0].Marshal())
+	tr.AppendBytes("A1", as[1].Marshal())
+	return tr.ChallengeScalar("e", primitives.BN254)
+}
+
+// pow2 returns 2ⁱ in the BN254 scalar field
+func pow2(i int) *mod.Int {
+	return primitives.BN254.NewElementFromBig(new(big.Int).Lsh(big.NewInt(1), uint(i)))
+}
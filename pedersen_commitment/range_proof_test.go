@@ -0,0 +1,76 @@
+package pedersen_commitment
+
+import (
+	"commitment/primitives"
+	"github.com/drand/kyber/group/mod"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRangeProof(t *testing.T) {
+	pc := NewCommiter()
+	for _, v := range []int64{0, 1, 200, 255} {
+		m := primitives.BN254.NewElement(v)
+		r, _ := primitives.BN254.Rand()
+		c := pc.Commit(m, r)
+		proof, err := pc.ProveRange(m, r, 8, primitives.NewTranscript("test"))
+		assert.Nil(t, err)
+		assert.True(t, pc.VerifyRange(c, 8, proof, primitives.NewTranscript("test")), v)
+
+		assert.False(t, pc.VerifyRange(c, 7, proof, primitives.NewTranscript("test")), v)
+		assert.False(t, pc.VerifyRange(pc.AddConstant(c, primitives.BN254.NewElement(1)), 8, proof, primitives.NewTranscript("test")), v)
+		assert.False(t, pc.VerifyRange(c, 8, proof, primitives.NewTranscript("other")), v)
+	}
+
+	r, _ := primitives.BN254.Rand()
+	_, err := pc.ProveRange(primitives.BN254.NewElement(256), r, 8, primitives.NewTranscript("test"))
+	assert.NotNil(t, err)
+	// -1 is a huge field element
+	_, err = pc.ProveRange(primitives.BN254.NewElement(-1), r, 8, primitives.NewTranscript("test"))
+	assert.NotNil(t, err)
+}
+
+func TestRangeProofForgedBit(t *testing.T) {
+	pc := NewCommiter()
+	m := primitives.BN254.NewElement(5)
+	r, _ := primitives.BN254.Rand()
+	c := pc.Commit(m, r)
+	proof, err := pc.ProveRange(m, r, 4, primitives.NewTranscript("test"))
+	assert.Nil(t, err)
+	// the bit proofs must match their commitments
+	proof.Proofs[0], proof.Proofs[1] = proof.Proofs[1], proof.Proofs[0]
+	assert.False(t, pc.VerifyRange(c, 4, proof, primitives.NewTranscript("test")))
+
+	proof.Proofs[0], proof.Proofs[1] = proof.Proofs[1], proof.Proofs[0]
+	proof.Proofs[2].E0 = new(mod.Int).Add(proof.Proofs[2].E0, primitives.BN254.NewElement(1)).(*mod.Int)
+	assert.False(t, pc.VerifyRange(c, 4, proof, primitives.NewTranscript("test")))
+}
+
+func TestRangeProofMalformed(t *testing.T) {
+	pc := NewCommiter()
+	m := primitives.BN254.NewElement(5)
+	r, _ := primitives.BN254.Rand()
+	c := pc.Commit(m, r)
+	assert.False(t, pc.VerifyRange(c, 4, nil, primitives.NewTranscript("test")))
+	assert.False(t, pc.VerifyRange(c, 4, &RangeProof{}, primitives.NewTranscript("test")))
+
+	fresh := func() *RangeProof {
+		proof, err := pc.ProveRange(m, r, 4, primitives.NewTranscript("test"))
+		assert.Nil(t, err)
+		return proof
+	}
+	for _, malform := range []func(*RangeProof){
+		func(p *RangeProof) { p.Bits[1] = nil },
+		func(p *RangeProof) { p.Proofs[1] = nil },
+		func(p *RangeProof) { p.Proofs[1].A0 = nil },
+		func(p *RangeProof) { p.Proofs[1].A1 = nil },
+		func(p *RangeProof) { p.Proofs[1].E0 = nil },
+		func(p *RangeProof) { p.Proofs[1].Z0 = nil },
+		func(p *RangeProof) { p.Proofs[1].Z1 = nil },
+	} {
+		proof := fresh()
+		malform(proof)
+		assert.False(t, pc.VerifyRange(c, 4, proof, primitives.NewTranscript("test")))
+	}
+	assert.False(t, pc.VerifyRange(nil, 4, fresh(), primitives.NewTranscript("test")))
+}
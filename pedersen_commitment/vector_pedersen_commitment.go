@@ -0,0 +1,70 @@
+package pedersen_commitment
+
+import (
+	"bytes"
+	"commitment/primitives"
+	"encoding/binary"
+	"fmt"
+	"github.com/drand/kyber/group/mod"
+	"github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"math/big"
+)
+
+// VectorCommiter commits to vectors of n field elements with one point:
+// c = ⟨m, G⟩ + r·H. The bases are hashed to the curve, so nobody knows
+// their discrete logarithms to each other.
+type VectorCommiter struct {
+	G []*bn256.G1
+	H *bn256.G1
+}
+
+// NewVectorCommiter returns a commiter of vectors of up to n elements
+func NewVectorCommiter(n int) *VectorCommiter {
+	g := make([]*bn256.G1, n)
+	for i := range g {
+		var b [8]byte
+		binary.BigEndian.PutUint64(b[:], uint64(i))
+		g[i] = primitives.HashToBN254G1("pedersen_commitment G", b[:]).P
+	}
+	return &VectorCommiter{G: g, H: primitives.HashToBN254G1("pedersen_commitment H", nil).P}
+}
+
+// Commit returns ⟨m, G⟩ + r·H
+func (vc *VectorCommiter) Commit(m []*mod.Int, r *mod.Int) (*bn256.G1, error) {
+	if len(m) > len(vc.G) {
+		return nil, fmt.Errorf("the commiter supports vectors of %d elements, got %d", len(vc.G), len(m))
+	}
+	c := new(bn256.G1).ScalarMult(vc.H, &r.V)
+	for i := range m {
+		c.Add(c, new(bn256.G1).ScalarMult(vc.G[i], &m[i].V))
+	}
+	return c, nil
+}
+
+// Verify checks that c opens to m with the blinding factor r
+func (vc *VectorCommiter) Verify(c *bn256.G1, m []*mod.Int, r *mod.Int) bool {
+	if c == nil || r == nil {
+		return false
+	}
+	cc, err := vc.Commit(m, r)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(cc.Marshal(), c.Marshal())
+}
+
+// Combine returns Σ kᵢ·cᵢ, the commitment to Σ kᵢ·mᵢ with the blinding
+// factor Σ kᵢ·rᵢ
+func Combine(cs []*bn256.G1, ks []*mod.Int) (*bn256.G1, error) {
+	if len(cs) != len(ks) {
+		return nil, fmt.Errorf("got %d commitments and %d factors", len(cs), len(ks))
+	}
+	r := new(bn256.G1).ScalarBaseMult(new(big.Int))
+	for i := range cs {
+		if cs[i] == nil || ks[i] == nil {
+			return nil, fmt.Errorf("the commitment or the factor %d is missing", i)
+		}
+		r.Add(r, new(bn256.G1).ScalarMult(cs[i], &ks[i].V))
+	}
+	return r, nil
+}
@@ -0,0 +1,38 @@
+package pedersen_commitment
+
+import (
+	"commitment/primitives"
+	"github.com/drand/kyber/group/mod"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestVectorCommiter(t *testing.T) {
+	vc := NewVectorCommiter(4)
+	m := []*mod.Int{primitives.BN254.NewElement(1), primitives.BN254.NewElement(2), primitives.BN254.NewElement(3)}
+	r, _ := primitives.BN254.Rand()
+	c, err := vc.Commit(m, r)
+	assert.Nil(t, err)
+	assert.True(t, vc.Verify(c, m, r))
+	assert.False(t, vc.Verify(c, m[:2], r))
+	assert.False(t, vc.Verify(c, m, primitives.BN254.NewElement(0)))
+
+	// homomorphism: 2·c₁ + 3·c₂ opens to 2·m₁ + 3·m₂
+	m2 := []*mod.Int{primitives.BN254.NewElement(5), primitives.BN254.NewElement(0), primitives.BN254.NewElement(7)}
+	r2, _ := primitives.BN254.Rand()
+	c2, _ := vc.Commit(m2, r2)
+	two, three := primitives.BN254.NewElement(2), primitives.BN254.NewElement(3)
+	sum := []*mod.Int{primitives.BN254.NewElement(17), primitives.BN254.NewElement(4), primitives.BN254.NewElement(27)}
+	rSum := new(mod.Int).Add(new(mod.Int).Mul(two, r), new(mod.Int).Mul(three, r2)).(*mod.Int)
+	combined, err := Combine([]*bn256.G1{c, c2}, []*mod.Int{two, three})
+	assert.Nil(t, err)
+	assert.True(t, vc.Verify(combined, sum, rSum))
+	_, err = Combine([]*bn256.G1{c, nil}, []*mod.Int{two, three})
+	assert.NotNil(t, err)
+	_, err = Combine([]*bn256.G1{c}, []*mod.Int{two, three})
+	assert.NotNil(t, err)
+
+	_, err = vc.Commit(append(m, m...), r)
+	assert.NotNil(t, err)
+}
@@ -19,20 +19,97 @@ func NewPoly(coeffs []*big.Int) Poly {
 	return Poly{coeffs, len(coeffs)}
 }
 
-func RandBigInt() (*big.Int, error) {
-	maxbits := R.BitLen()
-	b := make([]byte, (maxbits/8)-1)
-	_, err := rand.Read(b)
-	if err != nil {
-		return nil, err
-	}
-	r := new(big.Int).SetBytes(b)
-	rq := new(big.Int).Mod(r, R)
+// BigField does the arithmetic of a Field on big.Int values and on the Poly
+// polynomials of big.Int coefficients
+type BigField struct {
+	Field
+}
 
-	return rq, nil
+// bn254Big is the field of the package level functions below, which predate
+// the Field interface
+var bn254Big = BigField{BN254}
+
+func RandBigInt() (*big.Int, error) {
+	return bn254Big.RandBigInt()
 }
 
 func ArrayOfZeroes(n int) Poly {
+	return bn254Big.Zeroes(n)
+}
+
+func FieldAdd(a, b *big.Int) *big.Int {
+	return bn254Big.Add(a, b)
+}
+
+func FieldSub(a, b *big.Int) *big.Int {
+	return bn254Big.Sub(a, b)
+}
+
+func FieldMul(a, b *big.Int) *big.Int {
+	return bn254Big.Mul(a, b)
+}
+
+func FieldDiv(a, b *big.Int) *big.Int {
+	return bn254Big.Div(a, b)
+}
+
+func FieldNeg(a *big.Int) *big.Int {
+	return bn254Big.Neg(a)
+}
+
+func FieldInv(a *big.Int) *big.Int {
+	return bn254Big.Inv(a)
+}
+
+func FieldExp(base *big.Int, e *big.Int) *big.Int {
+	return bn254Big.Exp(base, e)
+}
+
+func PolynomialAdd(a, b Poly) Poly {
+	return bn254Big.PolynomialAdd(a, b)
+}
+
+func PolynomialSub(a, b Poly) Poly {
+	return bn254Big.PolynomialSub(a, b)
+}
+
+func PolynomialMul(a, b Poly) Poly {
+	return bn254Big.PolynomialMul(a, b)
+}
+
+func PolynomialDiv(a, b Poly) (Poly, Poly) {
+	return bn254Big.PolynomialDiv(a, b)
+}
+
+func PolynomialMulByConstant(a Poly, c *big.Int) Poly {
+	return bn254Big.PolynomialMulByConstant(a, c)
+}
+
+func PolynomialDivByConstant(a Poly, c *big.Int) Poly {
+	return bn254Big.PolynomialDivByConstant(a, c)
+}
+
+func PolynomialEval(p Poly, x *big.Int) *big.Int {
+	return bn254Big.PolynomialEval(p, x)
+}
+
+func NewPolZeroAt(pointPos, totalPoints int, height *big.Int) Poly {
+	return bn254Big.NewPolZeroAt(pointPos, totalPoints, height)
+}
+
+func ZeroPolynomial(zs []*big.Int) Poly {
+	return bn254Big.ZeroPolynomial(zs)
+}
+
+func LagrangeInterpolation(x, y []*big.Int) (Poly, error) {
+	return bn254Big.LagrangeInterpolation(x, y)
+}
+
+func (f BigField) RandBigInt() (*big.Int, error) {
+	return rand.Int(rand.Reader, f.Modulus())
+}
+
+func (f BigField) Zeroes(n int) Poly {
 	r := make([]*big.Int, n)
 	for i := 0; i < n; i++ {
 		r[i] = new(big.Int).SetInt64(0)
@@ -76,36 +153,35 @@ func checkArrayOfZeroes(a Poly) bool {
 	return ComparePoly(a, z)
 }
 
-func FieldAdd(a, b *big.Int) *big.Int {
+func (f BigField) Add(a, b *big.Int) *big.Int {
 	ab := new(big.Int).Add(a, b)
-	return ab.Mod(ab, R)
+	return ab.Mod(ab, f.Modulus())
 }
 
-func FieldSub(a, b *big.Int) *big.Int {
+func (f BigField) Sub(a, b *big.Int) *big.Int {
 	ab := new(big.Int).Sub(a, b)
-	return new(big.Int).Mod(ab, R)
+	return new(big.Int).Mod(ab, f.Modulus())
 }
 
-func FieldMul(a, b *big.Int) *big.Int {
+func (f BigField) Mul(a, b *big.Int) *big.Int {
 	ab := new(big.Int).Mul(a, b)
-	return ab.Mod(ab, R)
+	return ab.Mod(ab, f.Modulus())
 }
 
-func FieldDiv(a, b *big.Int) *big.Int {
-	ab := new(big.Int).Mul(a, new(big.Int).ModInverse(b, R))
-	return new(big.Int).Mod(ab, R)
+func (f BigField) Div(a, b *big.Int) *big.Int {
+	ab := new(big.Int).Mul(a, new(big.Int).ModInverse(b, f.Modulus()))
+	return new(big.Int).Mod(ab, f.Modulus())
 }
 
-func FieldNeg(a *big.Int) *big.Int {
-	return new(big.Int).Mod(new(big.Int).Neg(a), R)
+func (f BigField) Neg(a *big.Int) *big.Int {
+	return new(big.Int).Mod(new(big.Int).Neg(a), f.Modulus())
 }
 
-//nolint:deadcode,unused
-func FieldInv(a *big.Int) *big.Int {
-	return new(big.Int).ModInverse(a, R)
+func (f BigField) Inv(a *big.Int) *big.Int {
+	return new(big.Int).ModInverse(a, f.Modulus())
 }
 
-func FieldExp(base *big.Int, e *big.Int) *big.Int {
+func (f BigField) Exp(base *big.Int, e *big.Int) *big.Int {
 	res := big.NewInt(1)
 	rem := new(big.Int).Set(e)
 	exp := base
@@ -113,9 +189,9 @@ func FieldExp(base *big.Int, e *big.Int) *big.Int {
 	for !bytes.Equal(rem.Bytes(), big.NewInt(int64(0)).Bytes()) {
 		// if BigIsOdd(rem) {
 		if rem.Bit(0) == 1 { // .Bit(0) returns 1 when is odd
-			res = FieldMul(res, exp)
+			res = f.Mul(res, exp)
 		}
-		exp = FieldMul(exp, exp)
+		exp = f.Mul(exp, exp)
 		rem.Rsh(rem, 1)
 	}
 	return res
@@ -130,81 +206,81 @@ func max(a, b int) int {
 
 // polynomial operation.
 
-func PolynomialAdd(a, b Poly) Poly {
-	r := ArrayOfZeroes(max(a.Degree, b.Degree))
+func (f BigField) PolynomialAdd(a, b Poly) Poly {
+	r := f.Zeroes(max(a.Degree, b.Degree))
 	for i := 0; i < a.Degree; i++ {
-		r.Coefficient[i] = FieldAdd(r.Coefficient[i], a.Coefficient[i])
+		r.Coefficient[i] = f.Add(r.Coefficient[i], a.Coefficient[i])
 	}
 	for i := 0; i < b.Degree; i++ {
-		r.Coefficient[i] = FieldAdd(r.Coefficient[i], b.Coefficient[i])
+		r.Coefficient[i] = f.Add(r.Coefficient[i], b.Coefficient[i])
 	}
 	return r
 }
 
-func PolynomialSub(a, b Poly) Poly {
-	r := ArrayOfZeroes(max(a.Degree, b.Degree))
+func (f BigField) PolynomialSub(a, b Poly) Poly {
+	r := f.Zeroes(max(a.Degree, b.Degree))
 	for i := 0; i < a.Degree; i++ {
-		r.Coefficient[i] = FieldAdd(r.Coefficient[i], a.Coefficient[i])
+		r.Coefficient[i] = f.Add(r.Coefficient[i], a.Coefficient[i])
 	}
 	for i := 0; i < b.Degree; i++ {
-		r.Coefficient[i] = FieldSub(r.Coefficient[i], b.Coefficient[i])
+		r.Coefficient[i] = f.Sub(r.Coefficient[i], b.Coefficient[i])
 	}
 	return r
 }
 
-func PolynomialMul(a, b Poly) Poly {
-	r := ArrayOfZeroes(a.Degree + b.Degree - 1)
+func (f BigField) PolynomialMul(a, b Poly) Poly {
+	r := f.Zeroes(a.Degree + b.Degree - 1)
 	for i := 0; i < a.Degree; i++ {
 		for j := 0; j < b.Degree; j++ {
-			r.Coefficient[i+j] = FieldAdd(r.Coefficient[i+j], FieldMul(a.Coefficient[i], b.Coefficient[j]))
+			r.Coefficient[i+j] = f.Add(r.Coefficient[i+j], f.Mul(a.Coefficient[i], b.Coefficient[j]))
 		}
 	}
 	return r
 }
 
-func PolynomialDiv(a, b Poly) (Poly, Poly) {
+func (f BigField) PolynomialDiv(a, b Poly) (Poly, Poly) {
 	// https://en.wikipedia.org/wiki/Division_algorithm
-	r := ArrayOfZeroes(a.Degree - b.Degree + 1)
+	r := f.Zeroes(a.Degree - b.Degree + 1)
 	rem := a
 	for rem.Degree >= b.Degree {
-		l := FieldDiv(rem.Coefficient[rem.Degree-1], b.Coefficient[b.Degree-1])
+		l := f.Div(rem.Coefficient[rem.Degree-1], b.Coefficient[b.Degree-1])
 		pos := rem.Degree - b.Degree
 		r.Coefficient[pos] = l
-		aux := ArrayOfZeroes(pos)
+		aux := f.Zeroes(pos)
 		aux1 := append(aux.Coefficient, l)
 		tempoly := Poly{aux1, len(aux1)}
-		aux2 := PolynomialSub(rem, PolynomialMul(b, tempoly))
+		aux2 := f.PolynomialSub(rem, f.PolynomialMul(b, tempoly))
 		rem.Coefficient = aux2.Coefficient[:aux2.Degree-1]
 	}
 	return r, rem
 }
 
-func PolynomialMulByConstant(a Poly, c *big.Int) Poly {
+func (f BigField) PolynomialMulByConstant(a Poly, c *big.Int) Poly {
 	for i := 0; i < a.Degree; i++ {
-		a.Coefficient[i] = FieldMul(a.Coefficient[i], c)
+		a.Coefficient[i] = f.Mul(a.Coefficient[i], c)
 	}
 	return a
 }
-func PolynomialDivByConstant(a Poly, c *big.Int) Poly {
+func (f BigField) PolynomialDivByConstant(a Poly, c *big.Int) Poly {
 	for i := 0; i < a.Degree; i++ {
-		a.Coefficient[i] = FieldDiv(a.Coefficient[i], c)
+		a.Coefficient[i] = f.Div(a.Coefficient[i], c)
 	}
 	return a
 }
 
 // polynomialEval evaluates the polinomial over the Finite Field at the given value x
-func PolynomialEval(p Poly, x *big.Int) *big.Int {
+func (f BigField) PolynomialEval(p Poly, x *big.Int) *big.Int {
 	r := big.NewInt(int64(0))
 	for i := 0; i < p.Degree; i++ {
-		xi := FieldExp(x, big.NewInt(int64(i)))
-		elem := FieldMul(p.Coefficient[i], xi)
-		r = FieldAdd(r, elem)
+		xi := f.Exp(x, big.NewInt(int64(i)))
+		elem := f.Mul(p.Coefficient[i], xi)
+		r = f.Add(r, elem)
 	}
 	return r
 }
 
 // newPolZeroAt generates a new polynomial that has value zero at the given value
-func NewPolZeroAt(pointPos, totalPoints int, height *big.Int) Poly {
+func (f BigField) NewPolZeroAt(pointPos, totalPoints int, height *big.Int) Poly {
 	fac := 1
 	for i := 1; i < totalPoints+1; i++ {
 		if i != pointPos {
@@ -212,13 +288,13 @@ func NewPolZeroAt(pointPos, totalPoints int, height *big.Int) Poly {
 		}
 	}
 	facBig := big.NewInt(int64(fac))
-	hf := FieldDiv(height, facBig)
+	hf := f.Div(height, facBig)
 	r := Poly{[]*big.Int{hf}, 1}
 	for i := 1; i < totalPoints+1; i++ {
 		if i != pointPos {
 			ineg := big.NewInt(int64(-i))
 			b1 := big.NewInt(int64(1))
-			r = PolynomialMul(r, Poly{[]*big.Int{ineg, b1}, 2})
+			r = f.PolynomialMul(r, Poly{[]*big.Int{ineg, b1}, 2})
 		}
 	}
 	return r
@@ -226,10 +302,10 @@ func NewPolZeroAt(pointPos, totalPoints int, height *big.Int) Poly {
 
 // zeroPolynomial returns the zero polynomial:
 // z(x) = (x - z_0) (x - z_1) ... (x - z_{k-1})
-func ZeroPolynomial(zs []*big.Int) Poly {
-	z := Poly{[]*big.Int{FieldNeg(zs[0]), big.NewInt(1)}, 2} // (x - z0)
+func (f BigField) ZeroPolynomial(zs []*big.Int) Poly {
+	z := Poly{[]*big.Int{f.Neg(zs[0]), big.NewInt(1)}, 2} // (x - z0)
 	for i := 1; i < len(zs); i++ {
-		z = PolynomialMul(z, Poly{[]*big.Int{FieldNeg(zs[i]), big.NewInt(1)}, 2}) // (x - zi)
+		z = f.PolynomialMul(z, Poly{[]*big.Int{f.Neg(zs[i]), big.NewInt(1)}, 2}) // (x - zi)
 	}
 	return z
 }
@@ -273,13 +349,13 @@ func PolynomialToString(p Poly) string {
 
 // LagrangeInterpolation implements the Lagrange interpolation:
 // https://en.wikipedia.org/wiki/Lagrange_polynomial
-func LagrangeInterpolation(x, y []*big.Int) (Poly, error) {
+func (f BigField) LagrangeInterpolation(x, y []*big.Int) (Poly, error) {
 	// p(x) will be the interpoled polynomial
 	// var p []*big.Int
 	if len(x) != len(y) {
 		return Poly{nil, 0}, fmt.Errorf("len(x)!=len(y): %d, %d", len(x), len(y))
 	}
-	p := ArrayOfZeroes(len(x))
+	p := f.Zeroes(len(x))
 	k := len(x)
 
 	for j := 0; j < k; j++ {
@@ -291,17 +367,17 @@ func LagrangeInterpolation(x, y []*big.Int) (Poly, error) {
 				continue
 			}
 			// numerator & denominator of the current iteration
-			num := Poly{[]*big.Int{FieldNeg(x[m]), big.NewInt(1)}, 2} // (x^1 - x_m)
-			den := FieldSub(x[j], x[m])                               // x_j-x_m
-			mPol := PolynomialDivByConstant(num, den)
+			num := Poly{[]*big.Int{f.Neg(x[m]), big.NewInt(1)}, 2} // (x^1 - x_m)
+			den := f.Sub(x[j], x[m])                               // x_j-x_m
+			mPol := f.PolynomialDivByConstant(num, den)
 			if jPol.Degree == 0 {
 				// first j iteration
 				jPol = mPol
 				continue
 			}
-			jPol = PolynomialMul(jPol, mPol)
+			jPol = f.PolynomialMul(jPol, mPol)
 		}
-		p = PolynomialAdd(p, PolynomialMulByConstant(jPol, y[j]))
+		p = f.PolynomialAdd(p, f.PolynomialMulByConstant(jPol, y[j]))
 	}
 
 	return p, nil
@@ -166,3 +166,33 @@ func TestZeroPolynomial(t *testing.T) {
 	assert.Equal(t, "0", PolynomialEval(z, x1).String())
 	assert.Equal(t, "0", PolynomialEval(z, x2).String())
 }
+
+func TestBigField(t *testing.T) {
+	f := BigField{Goldilocks}
+	p := Goldilocks.Modulus()
+	assert.Equal(t, 0, f.Add(new(big.Int).Sub(p, big.NewInt(1)), big.NewInt(1)).Sign())
+	assert.Equal(t, int64(1), f.Mul(f.Div(big.NewInt(1), big.NewInt(3)), big.NewInt(3)).Int64())
+
+	// x² + 1 over Goldilocks
+	q, err := f.LagrangeInterpolation([]*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(2)}, []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(5)})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(101), f.PolynomialEval(q, big.NewInt(10)).Int64())
+	r, err := f.RandBigInt()
+	assert.Nil(t, err)
+	assert.True(t, r.Cmp(p) < 0)
+
+	// the random elements cover the whole field, whatever its size
+	small := BigField{FieldOf(big.NewInt(1009))}
+	high, nonzero := false, false
+	for i := 0; i < 20; i++ {
+		r, err := f.RandBigInt()
+		assert.Nil(t, err)
+		high = high || r.BitLen() > 56
+		s, err := small.RandBigInt()
+		assert.Nil(t, err)
+		assert.True(t, s.Cmp(big.NewInt(1009)) < 0)
+		nonzero = nonzero || s.Sign() != 0
+	}
+	assert.True(t, high)
+	assert.True(t, nonzero)
+}
@@ -0,0 +1,107 @@
+package primitives
+
+import (
+	"github.com/drand/kyber/group/mod"
+	"math/big"
+)
+
+// G1 is a point of the first source group of a pairing friendly curve.
+// Points are immutable, operations return new points.
+type G1 interface {
+	Curve() Curve
+	Add(b G1) G1
+	Neg() G1
+	ScalarMult(k *big.Int) G1
+	Equal(b G1) bool
+	Marshal() []byte
+}
+
+// G2 is a point of the second source group of a pairing friendly curve
+type G2 interface {
+	Add(b G2) G2
+	Neg() G2
+	ScalarMult(k *big.Int) G2
+	Equal(b G2) bool
+	Marshal() []byte
+}
+
+// GT is an element of the target group of a pairing
+type GT interface {
+	Equal(b GT) bool
+}
+
+// Curve is a pairing friendly elliptic curve e: 𝔾₁ × 𝔾₂ → 𝔾T whose groups
+// have the order of ScalarField
+type Curve interface {
+	Name() string
+	ScalarField() Field
+	// G1Generator returns the generator G of 𝔾₁
+	G1Generator() G1
+	// G2Generator returns the generator H of 𝔾₂
+	G2Generator() G2
+	UnmarshalG1(b []byte) (G1, error)
+	UnmarshalG2(b []byte) (G2, error)
+	Pair(a G1, b G2) GT
+	// PairingCheck returns true when ∏ e(aᵢ, bᵢ) = 1, computing a single
+	// final exponentiation for all the pairs
+	PairingCheck(a []G1, b []G2) bool
+}
+
+// PairingCheck returns true when ∏ e(aᵢ, bᵢ) = 1. Pairing based verifiers move
+// every pairing to one side of the equation by negating one operand, e.g.
+// e(A, B) == e(C, D) is checked as PairingCheck([A, -C], [B, D]).
+func PairingCheck(a []G1, b []G2) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	if len(a) == 0 {
+		return true
+	}
+	return a[0].Curve().PairingCheck(a, b)
+}
+
+// G1Zero returns the point at infinity of 𝔾₁
+func G1Zero(c Curve) G1 {
+	return c.G1Generator().ScalarMult(big.NewInt(0))
+}
+
+// G2Zero returns the point at infinity of 𝔾₂
+func G2Zero(c Curve) G2 {
+	return c.G2Generator().ScalarMult(big.NewInt(0))
+}
+
+// Scalars returns the values of the field elements, as the scalars of the
+// multi scalar multiplications
+func Scalars(vs []*mod.Int) []*big.Int {
+	ks := make([]*big.Int, len(vs))
+	for i := range vs {
+		ks[i] = &vs[i].V
+	}
+	return ks
+}
+
+// MultiScalarMultG1 returns Σ kᵢ·Pᵢ, the points and scalars must have the
+// same length
+func MultiScalarMultG1(c Curve, ps []G1, ks []*big.Int) G1 {
+	r := G1Zero(c)
+	for i := range ps {
+		if ks[i].Sign() == 0 {
+			continue
+		}
+		r = r.Add(ps[i].ScalarMult(ks[i]))
+	}
+	return r
+}
+
+// MultiScalarMultG2 returns Σ kᵢ·Pᵢ, the points and scalars must have the
+// same length
+func MultiScalarMultG2(c Curve, ps []G2, ks []*big.Int) G2 {
+	r := G2Zero(c)
+	for i := range ps {
+		if ks[i].Sign() == 0 {
+			continue
+		}
+		r = r.Add(ps[i].ScalarMult(ks[i]))
+	}
+	return r
+}
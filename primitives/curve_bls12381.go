@@ -0,0 +1,141 @@
+package primitives
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto/bls12381"
+)
+
+// CurveBLS12381 is the BLS12-381 curve, backed by the pure Go implementation
+// of go-ethereum
+var CurveBLS12381 Curve = bls12381Curve{}
+
+type bls12381Curve struct{}
+
+// BLS12381G1 is a point of 𝔾₁ on BLS12-381
+type BLS12381G1 struct {
+	P *bls12381.PointG1
+}
+
+// BLS12381G2 is a point of 𝔾₂ on BLS12-381
+type BLS12381G2 struct {
+	P *bls12381.PointG2
+}
+
+// BLS12381GT is an element of 𝔾T on BLS12-381
+type BLS12381GT struct {
+	P *bls12381.E
+}
+
+// the bls12381.G1 and bls12381.G2 groups hold scratch space, so a new one is
+// used for every operation to keep the points safe for concurrent use
+
+func (bls12381Curve) Name() string {
+	return "BLS12-381"
+}
+
+func (bls12381Curve) ScalarField() Field {
+	return BLS12381
+}
+
+func (bls12381Curve) G1Generator() G1 {
+	return &BLS12381G1{bls12381.NewG1().One()}
+}
+
+func (bls12381Curve) G2Generator() G2 {
+	return &BLS12381G2{bls12381.NewG2().One()}
+}
+
+func (bls12381Curve) UnmarshalG1(b []byte) (G1, error) {
+	g := bls12381.NewG1()
+	p, err := g.FromBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	if !g.InCorrectSubgroup(p) {
+		return nil, errors.New("point is not in the correct subgroup")
+	}
+	return &BLS12381G1{p}, nil
+}
+
+func (bls12381Curve) UnmarshalG2(b []byte) (G2, error) {
+	g := bls12381.NewG2()
+	p, err := g.FromBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	if !g.InCorrectSubgroup(p) {
+		return nil, errors.New("point is not in the correct subgroup")
+	}
+	return &BLS12381G2{p}, nil
+}
+
+func (bls12381Curve) Pair(a G1, b G2) GT {
+	e := bls12381.NewPairingEngine()
+	e.AddPair(new(bls12381.PointG1).Set(a.(*BLS12381G1).P), new(bls12381.PointG2).Set(b.(*BLS12381G2).P))
+	return &BLS12381GT{e.Result()}
+}
+
+func (bls12381Curve) PairingCheck(a []G1, b []G2) bool {
+	e := bls12381.NewPairingEngine()
+	for i := range a {
+		// AddPair converts the points to affine coordinates in place
+		e.AddPair(new(bls12381.PointG1).Set(a[i].(*BLS12381G1).P), new(bls12381.PointG2).Set(b[i].(*BLS12381G2).P))
+	}
+	return e.Check()
+}
+
+func (a *BLS12381G1) Curve() Curve {
+	return CurveBLS12381
+}
+
+func (a *BLS12381G1) Add(b G1) G1 {
+	g := bls12381.NewG1()
+	return &BLS12381G1{g.Add(g.New(), a.P, b.(*BLS12381G1).P)}
+}
+
+func (a *BLS12381G1) Neg() G1 {
+	g := bls12381.NewG1()
+	return &BLS12381G1{g.Neg(g.New(), a.P)}
+}
+
+func (a *BLS12381G1) ScalarMult(k *big.Int) G1 {
+	g := bls12381.NewG1()
+	return &BLS12381G1{g.MulScalar(g.New(), a.P, k)}
+}
+
+func (a *BLS12381G1) Equal(b G1) bool {
+	return bls12381.NewG1().Equal(a.P, b.(*BLS12381G1).P)
+}
+
+func (a *BLS12381G1) Marshal() []byte {
+	return bls12381.NewG1().ToBytes(new(bls12381.PointG1).Set(a.P))
+}
+
+func (a *BLS12381G2) Add(b G2) G2 {
+	g := bls12381.NewG2()
+	return &BLS12381G2{g.Add(g.New(), a.P, b.(*BLS12381G2).P)}
+}
+
+func (a *BLS12381G2) Neg() G2 {
+	g := bls12381.NewG2()
+	return &BLS12381G2{g.Neg(g.New(), a.P)}
+}
+
+func (a *BLS12381G2) ScalarMult(k *big.Int) G2 {
+	g := bls12381.NewG2()
+	return &BLS12381G2{g.MulScalar(g.New(), a.P, k)}
+}
+
+func (a *BLS12381G2) Equal(b G2) bool {
+	return bls12381.NewG2().Equal(a.P, b.(*BLS12381G2).P)
+}
+
+func (a *BLS12381G2) Marshal() []byte {
+	return bls12381.NewG2().ToBytes(new(bls12381.PointG2).Set(a.P))
+}
+
+func (a *BLS12381GT) Equal(b GT) bool {
+	return a.P.Equal(b.(*BLS12381GT).P)
+}
@@ -0,0 +1,129 @@
+package primitives
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+// CurveBN254 is the BN254 (alt_bn128) curve of go-ethereum's cloudflare bn256
+var CurveBN254 Curve = bn254Curve{}
+
+type bn254Curve struct{}
+
+// BN254G1 is a point of 𝔾₁ on BN254
+type BN254G1 struct {
+	P *bn256.G1
+}
+
+// BN254G2 is a point of 𝔾₂ on BN254
+type BN254G2 struct {
+	P *bn256.G2
+}
+
+// BN254GT is an element of 𝔾T on BN254
+type BN254GT struct {
+	P *bn256.GT
+}
+
+func (bn254Curve) Name() string {
+	return "BN254"
+}
+
+func (bn254Curve) ScalarField() Field {
+	return BN254
+}
+
+func (bn254Curve) G1Generator() G1 {
+	return &BN254G1{new(bn256.G1).ScalarBaseMult(big.NewInt(1))}
+}
+
+func (bn254Curve) G2Generator() G2 {
+	return &BN254G2{new(bn256.G2).ScalarBaseMult(big.NewInt(1))}
+}
+
+func (bn254Curve) UnmarshalG1(b []byte) (G1, error) {
+	p := new(bn256.G1)
+	if _, err := p.Unmarshal(b); err != nil {
+		return nil, err
+	}
+	return &BN254G1{p}, nil
+}
+
+// UnmarshalG2 rejects the points of the twist out of the subgroup of order
+// r. The cofactor of the twist is not 1, and the check of bn256 depends on
+// its version, so the order is checked here as well.
+func (bn254Curve) UnmarshalG2(b []byte) (G2, error) {
+	p := new(bn256.G2)
+	if _, err := p.Unmarshal(b); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(new(bn256.G2).ScalarMult(p, bn256.Order).Marshal(), new(bn256.G2).ScalarBaseMult(new(big.Int)).Marshal()) {
+		return nil, fmt.Errorf("bn256: point not in the subgroup of order r")
+	}
+	return &BN254G2{p}, nil
+}
+
+func (bn254Curve) Pair(a G1, b G2) GT {
+	return &BN254GT{bn256.Pair(a.(*BN254G1).P, b.(*BN254G2).P)}
+}
+
+func (bn254Curve) PairingCheck(a []G1, b []G2) bool {
+	ps := make([]*bn256.G1, len(a))
+	qs := make([]*bn256.G2, len(b))
+	for i := range a {
+		ps[i] = a[i].(*BN254G1).P
+		qs[i] = b[i].(*BN254G2).P
+	}
+	return bn256.PairingCheck(ps, qs)
+}
+
+func (a *BN254G1) Curve() Curve {
+	return CurveBN254
+}
+
+func (a *BN254G1) Add(b G1) G1 {
+	return &BN254G1{new(bn256.G1).Add(a.P, b.(*BN254G1).P)}
+}
+
+func (a *BN254G1) Neg() G1 {
+	return &BN254G1{new(bn256.G1).Neg(a.P)}
+}
+
+func (a *BN254G1) ScalarMult(k *big.Int) G1 {
+	return &BN254G1{new(bn256.G1).ScalarMult(a.P, k)}
+}
+
+func (a *BN254G1) Equal(b G1) bool {
+	return bytes.Equal(a.Marshal(), b.Marshal())
+}
+
+func (a *BN254G1) Marshal() []byte {
+	return a.P.Marshal()
+}
+
+func (a *BN254G2) Add(b G2) G2 {
+	return &BN254G2{new(bn256.G2).Add(a.P, b.(*BN254G2).P)}
+}
+
+func (a *BN254G2) Neg() G2 {
+	return &BN254G2{new(bn256.G2).Neg(a.P)}
+}
+
+func (a *BN254G2) ScalarMult(k *big.Int) G2 {
+	return &BN254G2{new(bn256.G2).ScalarMult(a.P, k)}
+}
+
+func (a *BN254G2) Equal(b G2) bool {
+	return bytes.Equal(a.Marshal(), b.Marshal())
+}
+
+func (a *BN254G2) Marshal() []byte {
+	return a.P.Marshal()
+}
+
+func (a *BN254GT) Equal(b GT) bool {
+	return bytes.Equal(a.P.Marshal(), b.(*BN254GT).P.Marshal())
+}
@@ -0,0 +1,73 @@
+package primitives
+
+import (
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+func TestCurve_Bilinearity(t *testing.T) {
+	for _, c := range []Curve{CurveBN254, CurveBLS12381} {
+		a, _ := c.ScalarField().Rand()
+		b, _ := c.ScalarField().Rand()
+		ab := new(big.Int).Mul(&a.V, &b.V)
+
+		g, h := c.G1Generator(), c.G2Generator()
+		// e(aG, bH) == e(abG, H)
+		e1 := c.Pair(g.ScalarMult(&a.V), h.ScalarMult(&b.V))
+		e2 := c.Pair(g.ScalarMult(ab), h)
+		assert.True(t, e1.Equal(e2), c.Name())
+		assert.False(t, e1.Equal(c.Pair(g, h)), c.Name())
+
+		// the group order is the modulus of the scalar field
+		assert.True(t, g.ScalarMult(c.ScalarField().Modulus()).Equal(G1Zero(c)), c.Name())
+		assert.True(t, g.Add(g.Neg()).Equal(G1Zero(c)), c.Name())
+	}
+}
+
+func TestCurve_Marshal(t *testing.T) {
+	for _, c := range []Curve{CurveBN254, CurveBLS12381} {
+		k := big.NewInt(12345)
+		p := c.G1Generator().ScalarMult(k)
+		q, err := c.UnmarshalG1(p.Marshal())
+		assert.Nil(t, err)
+		assert.True(t, p.Equal(q), c.Name())
+
+		p2 := c.G2Generator().ScalarMult(k)
+		q2, err := c.UnmarshalG2(p2.Marshal())
+		assert.Nil(t, err)
+		assert.True(t, p2.Equal(q2), c.Name())
+	}
+}
+
+func TestPairingCheck(t *testing.T) {
+	for _, c := range []Curve{CurveBN254, CurveBLS12381} {
+		a, _ := c.ScalarField().Rand()
+		b, _ := c.ScalarField().Rand()
+		ab := new(big.Int).Mul(&a.V, &b.V)
+		g, h := c.G1Generator(), c.G2Generator()
+
+		// e(aG, bH) · e(-abG, H) == 1
+		ps := []G1{g.ScalarMult(&a.V), g.ScalarMult(ab).Neg()}
+		qs := []G2{h.ScalarMult(&b.V), h}
+		assert.True(t, PairingCheck(ps, qs), c.Name())
+
+		qs[1] = h.ScalarMult(big.NewInt(2))
+		assert.False(t, PairingCheck(ps, qs), c.Name())
+		assert.False(t, PairingCheck(ps, qs[:1]), c.Name())
+	}
+}
+
+func TestUnmarshalG2_Subgroup(t *testing.T) {
+	// (1, y) on the twist y² = x³ + 3/(9+i), out of the subgroup of order r
+	b, _ := hex.DecodeString("0000000000000000000000000000000000000000000000000000000000000000" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"0d1271953ed9ea0836846e70a1934187998c7f790cb4d7511b7f8da82de048a4" +
+		"2869111d5381f072f8e2728fdb825a51aadd70e52c9830e9ab4b871c0531f1bb")
+	_, err := CurveBN254.UnmarshalG2(b)
+	assert.NotNil(t, err)
+
+	_, err = CurveBN254.UnmarshalG2(CurveBN254.G2Generator().Marshal())
+	assert.Nil(t, err)
+}
@@ -0,0 +1,138 @@
+package primitives
+
+import (
+	"crypto/rand"
+	"fmt"
+	"github.com/drand/kyber/group/mod"
+	"math/big"
+	"math/bits"
+	"sync"
+)
+
+// Field is a prime field 𝔽p. Its elements are mod.Int values carrying the
+// modulus of the field, so polynomials built from them are defined over it.
+type Field interface {
+	// Modulus returns the prime p, shared by all the elements of the field
+	Modulus() *big.Int
+	// NewElement returns v mod p
+	NewElement(v int64) *mod.Int
+	// NewElementFromBig returns v mod p
+	NewElementFromBig(v *big.Int) *mod.Int
+	// Rand returns a uniformly random element
+	Rand() (*mod.Int, error)
+	// RandVector returns n uniformly random elements
+	RandVector(n int) ([]*mod.Int, error)
+	// RootOfUnity returns a primitive n-th root of unity, n a power of two
+	RootOfUnity(n int) (*mod.Int, error)
+}
+
+// PrimeField is a Field given by its modulus and, when the field supports
+// NTTs, a generator of its multiplicative group
+type PrimeField struct {
+	modulus    *big.Int
+	generator  *big.Int
+	twoAdicity int // p-1 = 2^twoAdicity·t with t odd
+}
+
+// fields holds the fields created so far indexed by their modulus, so the
+// field of a mod.Int can be found back from its M
+var (
+	fields   = map[string]*PrimeField{}
+	fieldsMu sync.Mutex
+)
+
+// NewPrimeField returns the field of the integers modulo the given prime.
+// The generator may be nil, in which case the field has no roots of unity.
+func NewPrimeField(modulus, generator *big.Int) *PrimeField {
+	fieldsMu.Lock()
+	defer fieldsMu.Unlock()
+	if f, ok := fields[modulus.String()]; ok && (generator == nil || f.generator != nil) {
+		return f
+	}
+	f := newPrimeField(modulus, generator)
+	fields[modulus.String()] = f
+	return f
+}
+
+func newPrimeField(modulus, generator *big.Int) *PrimeField {
+	pm1 := new(big.Int).Sub(modulus, big.NewInt(1))
+	return &PrimeField{modulus, generator, int(pm1.TrailingZeroBits())}
+}
+
+// The scalar fields shipped with the package
+var (
+	// BN254 is the scalar field of the BN254 (alt_bn128) curve
+	BN254 = NewPrimeField(Q, big.NewInt(5))
+	// BLS12381 is the scalar field of the BLS12-381 curve
+	BLS12381 = NewPrimeField(bigFromHex("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001"), big.NewInt(7))
+	// Goldilocks is the 64 bits field of modulus 2⁶⁴ - 2³² + 1
+	Goldilocks = NewPrimeField(bigFromHex("ffffffff00000001"), big.NewInt(7))
+)
+
+// FieldOf returns the field of the given modulus: the one created by
+// NewPrimeField, or a field with no roots of unity which is not registered
+func FieldOf(m *big.Int) Field {
+	fieldsMu.Lock()
+	f, ok := fields[m.String()]
+	fieldsMu.Unlock()
+	if ok {
+		return f
+	}
+	return newPrimeField(m, nil)
+}
+
+func (f *PrimeField) Modulus() *big.Int {
+	return f.modulus
+}
+
+func (f *PrimeField) NewElement(v int64) *mod.Int {
+	return mod.NewInt64(v, f.modulus)
+}
+
+func (f *PrimeField) NewElementFromBig(v *big.Int) *mod.Int {
+	return mod.NewInt(v, f.modulus)
+}
+
+func (f *PrimeField) Rand() (*mod.Int, error) {
+	r, err := rand.Int(rand.Reader, f.modulus)
+	if err != nil {
+		return nil, err
+	}
+	return mod.NewInt(r, f.modulus), nil
+}
+
+func (f *PrimeField) RandVector(n int) ([]*mod.Int, error) {
+	v := make([]*mod.Int, n)
+	for i := range v {
+		e, err := f.Rand()
+		if err != nil {
+			return nil, err
+		}
+		v[i] = e
+	}
+	return v, nil
+}
+
+func (f *PrimeField) RootOfUnity(n int) (*mod.Int, error) {
+	if n <= 0 || n&(n-1) != 0 {
+		return nil, fmt.Errorf("the domain size must be a power of two, got %d", n)
+	}
+	if f.generator == nil {
+		return nil, fmt.Errorf("no roots of unity known for modulus %s", f.modulus.String())
+	}
+	logN := bits.TrailingZeros(uint(n))
+	if logN > f.twoAdicity {
+		return nil, fmt.Errorf("the field has no roots of unity of order %d", n)
+	}
+	// ω = g^((p-1)/n)
+	e := new(big.Int).Rsh(new(big.Int).Sub(f.modulus, big.NewInt(1)), uint(logN))
+	return new(mod.Int).Exp(mod.NewInt(f.generator, f.modulus), e).(*mod.Int), nil
+}
+
+func bigFromHex(s string) *big.Int {
+	r, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("invalid hex number " + s)
+	}
+	return r
+}
@@ -0,0 +1,96 @@
+package primitives
+
+import (
+	"github.com/drand/kyber/group/mod"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+func randPolynomialOver(t *testing.T, f Field, n int) *Polynomial {
+	c, err := f.RandVector(n)
+	assert.Nil(t, err)
+	return new(Polynomial).Init(c)
+}
+
+func TestPrimeField_RootOfUnity(t *testing.T) {
+	for _, f := range []Field{BN254, BLS12381, Goldilocks} {
+		omega, err := f.RootOfUnity(1 << 10)
+		assert.Nil(t, err)
+		// ω^(2⁹) = -1 so ω has order exactly 2¹⁰
+		half := new(mod.Int).Exp(omega, big.NewInt(1<<9))
+		assert.True(t, half.Equal(new(mod.Int).Neg(f.NewElement(1))))
+	}
+
+	_, err := BN254.RootOfUnity(1 << 29)
+	assert.NotNil(t, err)
+	_, err = FieldOf(big.NewInt(101)).RootOfUnity(4)
+	assert.NotNil(t, err)
+	assert.Equal(t, BLS12381, FieldOf(BLS12381.Modulus()))
+}
+
+func TestPrimeField_RandVector(t *testing.T) {
+	v, err := Goldilocks.RandVector(5)
+	assert.Nil(t, err)
+	assert.Len(t, v, 5)
+	for _, e := range v {
+		assert.Equal(t, Goldilocks.Modulus(), e.M)
+	}
+	assert.False(t, v[0].Equal(v[1]))
+	v, err = BN254.RandVector(0)
+	assert.Nil(t, err)
+	assert.Empty(t, v)
+}
+
+func TestPolynomial_SmallField(t *testing.T) {
+	// 𝔽₉₇, 97 - 1 = 2⁵·3
+	f := NewPrimeField(big.NewInt(97), big.NewInt(5))
+	a := new(Polynomial).Init([]*mod.Int{f.NewElement(1), f.NewElement(0), f.NewElement(5)}) // 1 + 5x²
+	b := new(Polynomial).Init([]*mod.Int{f.NewElement(3), f.NewElement(0), f.NewElement(1)}) // 3 + x²
+
+	o := new(Polynomial).Sub(a, b)
+	assert.Equal(t, int64(95), o.Coefficient[0].Int64())
+
+	q, r := new(Polynomial).Div(a, b)
+	assert.Equal(t, int64(5), q.Coefficient[0].Int64())
+	assert.Equal(t, int64(97-14), r.Coefficient[0].Int64())
+
+	xs := []*mod.Int{f.NewElement(2), f.NewElement(50), f.NewElement(96)}
+	ys := a.BatchEval(xs)
+	i, err := new(Polynomial).LagrangeInterpolation(xs, ys)
+	assert.Nil(t, err)
+	assertPolyEqual(t, a, i)
+	assert.Equal(t, f.Modulus(), i.Coefficient[2].M)
+
+	evals, err := NTT(a.Coefficient, 32)
+	assert.Nil(t, err)
+	coeffs, err := InverseNTT(evals)
+	assert.Nil(t, err)
+	assertPolyEqual(t, a, new(Polynomial).Init(coeffs))
+}
+
+func TestPolynomial_OtherFields(t *testing.T) {
+	for _, f := range []Field{BLS12381, Goldilocks} {
+		a := randPolynomialOver(t, f, 150)
+		b := randPolynomialOver(t, f, 70)
+
+		q, r := new(Polynomial).Div(a, b)
+		assertPolyEqual(t, a, new(Polynomial).Add(new(Polynomial).Mul(q, b), r))
+		assert.Equal(t, f.Modulus(), q.Coefficient[0].M)
+
+		xs := randPolynomialOver(t, f, 20).Coefficient
+		ys := a.MultiEval(xs)
+		for k := range xs {
+			assert.True(t, ys[k].Equal(a.Eval(xs[k])))
+		}
+	}
+}
+
+func TestFieldOf(t *testing.T) {
+	before := len(fields)
+	f := FieldOf(big.NewInt(1009))
+	assert.Equal(t, big.NewInt(1009), f.Modulus())
+	// unknown moduli are not registered
+	assert.Equal(t, before, len(fields))
+	assert.Equal(t, Goldilocks, FieldOf(Goldilocks.Modulus()))
+}
@@ -0,0 +1,48 @@
+package primitives
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+// HashToBN254G1 maps a message to a point of 𝔾₁ on BN254 whose discrete
+// logarithm is unknown, by try-and-increment: x = sha256(domain ‖ msg ‖ i)
+// for the first counter i such that x³ + 3 is a square, y being its even
+// square root. 𝔾₁ has cofactor 1, so every point of the curve is in 𝔾₁.
+// The running time depends on the message, do not hash secrets with it.
+func HashToBN254G1(domain string, msg []byte) *BN254G1 {
+	b := make([]byte, 64)
+	three := big.NewInt(3)
+	for i := uint32(0); ; i++ {
+		var counter [4]byte
+		binary.BigEndian.PutUint32(counter[:], i)
+		h := sha256.New()
+		h.Write([]byte(domain))
+		h.Write(msg)
+		h.Write(counter[:])
+		x := new(big.Int).SetBytes(h.Sum(nil))
+		x.Mod(x, bn256.P)
+
+		// y² = x³ + 3
+		y2 := new(big.Int).Exp(x, three, bn256.P)
+		y2.Add(y2, three).Mod(y2, bn256.P)
+		y := new(big.Int).ModSqrt(y2, bn256.P)
+		if y == nil {
+			continue
+		}
+		if y.Bit(0) == 1 {
+			y.Sub(bn256.P, y)
+		}
+		x.FillBytes(b[:32])
+		y.FillBytes(b[32:])
+		p := new(bn256.G1)
+		if _, err := p.Unmarshal(b); err != nil {
+			// x = 0 with y = 0 is the encoding of the point at infinity
+			continue
+		}
+		return &BN254G1{p}
+	}
+}
@@ -0,0 +1,20 @@
+package primitives
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestHashToBN254G1(t *testing.T) {
+	p := HashToBN254G1("test", []byte("a"))
+	assert.True(t, p.Equal(HashToBN254G1("test", []byte("a"))))
+	assert.False(t, p.Equal(HashToBN254G1("test", []byte("b"))))
+	assert.False(t, p.Equal(HashToBN254G1("other", []byte("a"))))
+	assert.False(t, p.Equal(G1Zero(CurveBN254)))
+
+	// the point is on the curve and in 𝔾₁
+	q, err := CurveBN254.UnmarshalG1(p.Marshal())
+	assert.Nil(t, err)
+	assert.True(t, p.Equal(q))
+	assert.True(t, p.ScalarMult(BN254.Modulus()).Equal(G1Zero(CurveBN254)))
+}
@@ -0,0 +1,79 @@
+package primitives
+
+import (
+	"fmt"
+	"github.com/drand/kyber/group/mod"
+	"math/bits"
+)
+
+// MultilinearPoly is the multilinear extension of its evaluations over the
+// boolean hypercube {0,1}ⁿ: the unique polynomial of degree at most 1 in
+// each variable with f(b) = Evaluations[b]. The bit j of the index b is
+// the variable xⱼ.
+type MultilinearPoly struct {
+	Evaluations []*mod.Int
+	NumVars     int
+}
+
+// NewMultilinearPoly returns the multilinear extension of the evaluations,
+// whose number must be a power of two
+func NewMultilinearPoly(evaluations []*mod.Int) (*MultilinearPoly, error) {
+	n := len(evaluations)
+	if n == 0 || n&(n-1) != 0 {
+		return nil, fmt.Errorf("the number of evaluations must be a power of two, got %d", n)
+	}
+	return &MultilinearPoly{evaluations, bits.TrailingZeros(uint(n))}, nil
+}
+
+// EqPolynomial returns the evaluations of eq(x, r) = Πⱼ (xⱼrⱼ + (1-xⱼ)(1-rⱼ))
+// over the hypercube, the multilinear Lagrange basis at r: f(r) = Σ_b f(b)·eq(b, r)
+func EqPolynomial(f Field, r []*mod.Int) *MultilinearPoly {
+	evals := make([]*mod.Int, 1, 1<<len(r))
+	evals[0] = f.NewElement(1)
+	one := f.NewElement(1)
+	for _, rj := range r {
+		n := len(evals)
+		evals = evals[:2*n]
+		oneMinusR := new(mod.Int).Sub(one, rj)
+		for i := 0; i < n; i++ {
+			evals[i+n] = new(mod.Int).Mul(evals[i], rj).(*mod.Int)
+			evals[i] = new(mod.Int).Mul(evals[i], oneMinusR).(*mod.Int)
+		}
+	}
+	return &MultilinearPoly{evals, len(r)}
+}
+
+// Evaluate evaluates the polynomial at the point r ∈ Fⁿ
+func (p *MultilinearPoly) Evaluate(r []*mod.Int) (*mod.Int, error) {
+	if len(r) != p.NumVars {
+		return nil, fmt.Errorf("the polynomial has %d variables, got a point of %d", p.NumVars, len(r))
+	}
+	q := p
+	for _, rj := range r {
+		q = q.FixFirstVariable(rj)
+	}
+	return q.Evaluations[0], nil
+}
+
+// FixFirstVariable returns the polynomial in x₁, ..., xₙ₋₁ of f(r, x₁, ...)
+func (p *MultilinearPoly) FixFirstVariable(r *mod.Int) *MultilinearPoly {
+	half := len(p.Evaluations) / 2
+	evals := make([]*mod.Int, half)
+	for i := range evals {
+		// f(0, b) + r·(f(1, b) - f(0, b))
+		lo, hi := p.Evaluations[2*i], p.Evaluations[2*i+1]
+		evals[i] = new(mod.Int).Add(lo, new(mod.Int).Mul(r, new(mod.Int).Sub(hi, lo))).(*mod.Int)
+	}
+	return &MultilinearPoly{evals, p.NumVars - 1}
+}
+
+// FixLastVariable returns the polynomial in x₀, ..., xₙ₋₂ of f(..., xₙ₋₂, r)
+func (p *MultilinearPoly) FixLastVariable(r *mod.Int) *MultilinearPoly {
+	half := len(p.Evaluations) / 2
+	evals := make([]*mod.Int, half)
+	for i := range evals {
+		lo, hi := p.Evaluations[i], p.Evaluations[i+half]
+		evals[i] = new(mod.Int).Add(lo, new(mod.Int).Mul(r, new(mod.Int).Sub(hi, lo))).(*mod.Int)
+	}
+	return &MultilinearPoly{evals, p.NumVars - 1}
+}
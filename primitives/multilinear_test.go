@@ -0,0 +1,49 @@
+package primitives
+
+import (
+	"github.com/drand/kyber/group/mod"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMultilinearPoly_Evaluate(t *testing.T) {
+	// f(x₀, x₁) = 1 + 2x₀ + 3x₁ + 4x₀x₁
+	f := func(x0, x1 int64) *mod.Int { return BN254.NewElement(1 + 2*x0 + 3*x1 + 4*x0*x1) }
+	p, err := NewMultilinearPoly([]*mod.Int{f(0, 0), f(1, 0), f(0, 1), f(1, 1)})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, p.NumVars)
+
+	y, err := p.Evaluate([]*mod.Int{BN254.NewElement(5), BN254.NewElement(7)})
+	assert.Nil(t, err)
+	assert.True(t, y.Equal(f(5, 7)))
+
+	// fixing the variables in either order
+	first, _ := p.FixFirstVariable(BN254.NewElement(5)).Evaluate([]*mod.Int{BN254.NewElement(7)})
+	last, _ := p.FixLastVariable(BN254.NewElement(7)).Evaluate([]*mod.Int{BN254.NewElement(5)})
+	assert.True(t, first.Equal(y))
+	assert.True(t, last.Equal(y))
+
+	_, err = p.Evaluate([]*mod.Int{BN254.NewElement(5)})
+	assert.NotNil(t, err)
+	_, err = NewMultilinearPoly(p.Evaluations[:3])
+	assert.NotNil(t, err)
+}
+
+func TestEqPolynomial(t *testing.T) {
+	r := []*mod.Int{BN254.NewElement(3), BN254.NewElement(11), BN254.NewElement(2)}
+	eq := EqPolynomial(BN254, r)
+	assert.Equal(t, 8, len(eq.Evaluations))
+
+	// Σ_b f(b)·eq(b, r) = f(r)
+	evals := make([]*mod.Int, 8)
+	for i := range evals {
+		evals[i], _ = BN254.Rand()
+	}
+	p, _ := NewMultilinearPoly(evals)
+	sum := BN254.NewElement(0)
+	for i := range evals {
+		sum = new(mod.Int).Add(sum, new(mod.Int).Mul(evals[i], eq.Evaluations[i])).(*mod.Int)
+	}
+	y, _ := p.Evaluate(r)
+	assert.True(t, sum.Equal(y))
+}
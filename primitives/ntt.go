@@ -0,0 +1,182 @@
+package primitives
+
+import (
+	"fmt"
+	"github.com/drand/kyber/group/mod"
+	"math/big"
+	"math/bits"
+)
+
+// RootOfUnity returns a primitive n-th root of unity ω of the field of
+// modulus m, n must be a power of two
+func RootOfUnity(n int, m *big.Int) (*mod.Int, error) {
+	return FieldOf(m).RootOfUnity(n)
+}
+
+// NTT evaluates the polynomial with coefficients a at the n-th roots of
+// unity ω⁰, ω¹, ..., ωⁿ⁻¹, n must be a power of two and at least len(a)
+func NTT(a []*mod.Int, n int) ([]*mod.Int, error) {
+	if len(a) == 0 || len(a) > n {
+		return nil, fmt.Errorf("can not evaluate %d coefficients over a domain of size %d", len(a), n)
+	}
+	omega, err := RootOfUnity(n, a[0].M)
+	if err != nil {
+		return nil, err
+	}
+	return ntt(a, n, omega), nil
+}
+
+// InverseNTT interpolates the evaluations a over the len(a)-th roots of unity
+// and returns the coefficients of the polynomial
+func InverseNTT(a []*mod.Int) ([]*mod.Int, error) {
+	n := len(a)
+	if n == 0 {
+		return nil, fmt.Errorf("can not interpolate an empty domain")
+	}
+	omega, err := RootOfUnity(n, a[0].M)
+	if err != nil {
+		return nil, err
+	}
+	r := ntt(a, n, new(mod.Int).Inv(omega).(*mod.Int))
+	nInv := new(mod.Int).Inv(mod.NewInt64(int64(n), a[0].M))
+	for i := range r {
+		r[i].Mul(r[i], nInv)
+	}
+	return r, nil
+}
+
+// ntt is the iterative radix-2 Cooley-Tukey transform, it returns a new slice
+// and leaves a untouched
+func ntt(a []*mod.Int, n int, omega *mod.Int) []*mod.Int {
+	m := omega.M
+	logN := uint(bits.TrailingZeros(uint(n)))
+	v := make([]*big.Int, n)
+	for i := 0; i < n; i++ {
+		v[i] = new(big.Int)
+	}
+	for i := range a {
+		j := bits.Reverse(uint(i)) >> (bits.UintSize - logN)
+		v[j].Set(&a[i].V)
+	}
+
+	t := new(big.Int)
+	for size := 2; size <= n; size <<= 1 {
+		half := size / 2
+		// wm is a primitive size-th root of unity
+		wm := new(big.Int).Exp(&omega.V, big.NewInt(int64(n/size)), m)
+		ws := make([]*big.Int, half)
+		ws[0] = big.NewInt(1)
+		for k := 1; k < half; k++ {
+			ws[k] = new(big.Int).Mul(ws[k-1], wm)
+			ws[k].Mod(ws[k], m)
+		}
+		for start := 0; start < n; start += size {
+			for k := 0; k < half; k++ {
+				u := v[start+k]
+				w := v[start+k+half]
+				t.Mul(w, ws[k])
+				t.Mod(t, m)
+				w.Sub(u, t)
+				if w.Sign() < 0 {
+					w.Add(w, m)
+				}
+				u.Add(u, t)
+				if u.Cmp(m) >= 0 {
+					u.Sub(u, m)
+				}
+			}
+		}
+	}
+
+	r := make([]*mod.Int, n)
+	for i := range v {
+		r[i] = new(mod.Int).Init(v[i], m)
+	}
+	return r
+}
+
+// nttMulThreshold is the number of coefficients from which multiplying
+// through the NTT is faster than the schoolbook multiplication
+const nttMulThreshold = 64
+
+// mulNTT multiplies a and b with a convolution over the roots of unity, it
+// returns false when the field does not have a large enough domain
+func mulNTT(a, b *Polynomial) (*Polynomial, bool) {
+	size := a.Degree + b.Degree - 1
+	n := 1
+	for n < size {
+		n <<= 1
+	}
+	omega, err := RootOfUnity(n, a.Coefficient[0].M)
+	if err != nil {
+		return nil, false
+	}
+	m := omega.M
+	ea := ntt(a.Coefficient, n, omega)
+	eb := ntt(b.Coefficient, n, omega)
+	for i := 0; i < n; i++ {
+		ea[i].V.Mul(&ea[i].V, &eb[i].V)
+		ea[i].V.Mod(&ea[i].V, m)
+	}
+	r := ntt(ea, n, new(mod.Int).Inv(omega).(*mod.Int))
+	nInv := new(mod.Int).Inv(mod.NewInt64(int64(n), m))
+	for i := 0; i < size; i++ {
+		r[i].Mul(r[i], nInv)
+	}
+	return &Polynomial{r[:size], size}, true
+}
+
+// NTTG1 is the NTT over 𝔾₁: it returns Σⱼ ωⁱʲ·ps[j] for every i, where ω is a
+// primitive len(ps)-th root of unity of the curve's scalar field
+func NTTG1(ps []G1) ([]G1, error) {
+	omega, err := g1Root(ps)
+	if err != nil {
+		return nil, err
+	}
+	return nttG1(ps, omega), nil
+}
+
+// InverseNTTG1 is the inverse of NTTG1
+func InverseNTTG1(ps []G1) ([]G1, error) {
+	omega, err := g1Root(ps)
+	if err != nil {
+		return nil, err
+	}
+	r := nttG1(ps, new(mod.Int).Inv(omega).(*mod.Int))
+	nInv := new(mod.Int).Inv(mod.NewInt64(int64(len(ps)), omega.M)).(*mod.Int)
+	for i := range r {
+		r[i] = r[i].ScalarMult(&nInv.V)
+	}
+	return r, nil
+}
+
+func g1Root(ps []G1) (*mod.Int, error) {
+	if len(ps) == 0 {
+		return nil, fmt.Errorf("can not transform an empty domain")
+	}
+	return ps[0].Curve().ScalarField().RootOfUnity(len(ps))
+}
+
+func nttG1(ps []G1, omega *mod.Int) []G1 {
+	n := len(ps)
+	m := omega.M
+	logN := uint(bits.TrailingZeros(uint(n)))
+	v := make([]G1, n)
+	for i := range ps {
+		v[bits.Reverse(uint(i))>>(bits.UintSize-logN)] = ps[i]
+	}
+	for size := 2; size <= n; size <<= 1 {
+		half := size / 2
+		wm := new(big.Int).Exp(&omega.V, big.NewInt(int64(n/size)), m)
+		w := big.NewInt(1)
+		for k := 0; k < half; k++ {
+			for start := 0; start < n; start += size {
+				t := v[start+k+half].ScalarMult(w)
+				v[start+k+half] = v[start+k].Add(t.Neg())
+				v[start+k] = v[start+k].Add(t)
+			}
+			w = new(big.Int).Mod(new(big.Int).Mul(w, wm), m)
+		}
+	}
+	return v
+}
@@ -0,0 +1,113 @@
+package primitives
+
+import (
+	"github.com/drand/kyber/group/mod"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func assertPolyEqual(t *testing.T, a, b *Polynomial) {
+	a, b = a.trim(), b.trim()
+	assert.Equal(t, a.Degree, b.Degree)
+	for i := 0; i < a.Degree && i < b.Degree; i++ {
+		assert.True(t, a.Coefficient[i].Equal(b.Coefficient[i]), "coefficient %d", i)
+	}
+}
+
+func TestNTT(t *testing.T) {
+	p := randPolynomial(t, 13)
+	evals, err := NTT(p.Coefficient, 16)
+	assert.Nil(t, err)
+
+	omega, err := RootOfUnity(16, Q)
+	assert.Nil(t, err)
+	x := mod.NewInt64(1, Q)
+	for i := 0; i < 16; i++ {
+		assert.True(t, evals[i].Equal(p.Eval(x)))
+		x = new(mod.Int).Mul(x, omega).(*mod.Int)
+	}
+	// ω¹⁶ = 1
+	assert.True(t, x.Equal(mod.NewInt64(1, Q)))
+
+	coeffs, err := InverseNTT(evals)
+	assert.Nil(t, err)
+	assertPolyEqual(t, p, new(Polynomial).Init(coeffs))
+
+	_, err = NTT(p.Coefficient, 12)
+	assert.NotNil(t, err)
+}
+
+func TestPolynomial_MulNTT(t *testing.T) {
+	a := randPolynomial(t, 100)
+	b := randPolynomial(t, 70)
+	r, ok := mulNTT(a, b)
+	assert.True(t, ok)
+
+	// schoolbook product
+	s := new(Polynomial).InitFromZerosArray(a.Degree + b.Degree - 1)
+	for i := 0; i < a.Degree; i++ {
+		for j := 0; j < b.Degree; j++ {
+			s.Coefficient[i+j].Add(s.Coefficient[i+j], new(mod.Int).Mul(a.Coefficient[i], b.Coefficient[j]))
+		}
+	}
+	assert.Equal(t, s.Degree, r.Degree)
+	assertPolyEqual(t, s, r)
+}
+
+func TestPolynomial_DivNewton(t *testing.T) {
+	a := randPolynomial(t, 300)
+	b := randPolynomial(t, 90)
+
+	q, r := new(Polynomial).Div(a, b)
+	assert.Equal(t, 211, q.Degree)
+	assert.Equal(t, 89, r.Degree)
+	// a = q·b + r
+	assertPolyEqual(t, a, new(Polynomial).Add(new(Polynomial).Mul(q, b), r))
+
+	q2, r2, ok := divNewton(a, b)
+	assert.True(t, ok)
+	assertPolyEqual(t, q, q2)
+	assertPolyEqual(t, r, r2)
+}
+
+func TestPolynomial_DivByLinear(t *testing.T) {
+	a := randPolynomial(t, 50)
+	z := mod.NewInt64(7, Q)
+	q, r := new(Polynomial).DivByLinear(a, z)
+	assert.True(t, r.Equal(a.Eval(z)))
+
+	// a = q·(x - z) + r
+	d := new(Polynomial).Init([]*mod.Int{negf(z), mod.NewInt64(1, Q)})
+	qd := new(Polynomial).Mul(q, d)
+	assertPolyEqual(t, a, new(Polynomial).Add(qd, new(Polynomial).Init([]*mod.Int{r})))
+}
+
+func BenchmarkPolynomial_Div(b *testing.B) {
+	a := randPolynomial(b, 2048)
+	d := randPolynomial(b, 1024)
+	for i := 0; i < b.N; i++ {
+		new(Polynomial).Div(a, d)
+	}
+}
+
+func TestNTTG1(t *testing.T) {
+	g := CurveBN254.G1Generator()
+	p := randPolynomial(t, 8)
+	ps := make([]G1, 8)
+	for i := range ps {
+		ps[i] = g.ScalarMult(&p.Coefficient[i].V)
+	}
+	// [NTT(p)]₁ == NTT([p]₁)
+	evals, err := NTT(p.Coefficient, 8)
+	assert.Nil(t, err)
+	eps, err := NTTG1(ps)
+	assert.Nil(t, err)
+	for i := range eps {
+		assert.True(t, eps[i].Equal(g.ScalarMult(&evals[i].V)))
+	}
+	ips, err := InverseNTTG1(eps)
+	assert.Nil(t, err)
+	for i := range ips {
+		assert.True(t, ips[i].Equal(ps[i]))
+	}
+}
@@ -2,7 +2,6 @@ package primitives
 
 import (
 	"bytes"
-	"crypto/rand"
 	"fmt"
 	"github.com/drand/kyber/group/mod"
 	"math/big"
@@ -17,16 +16,7 @@ type Polynomial struct {
 
 // RandModInt returns a random number between 0 and Q-1
 func RandModInt() (*mod.Int, error) {
-	maxbits := R.BitLen()
-	b := make([]byte, (maxbits/8)-1)
-	_, err := rand.Read(b)
-	if err != nil {
-		return nil, err
-	}
-	r := new(big.Int).SetBytes(b)
-	rq := new(big.Int).Mod(r, Q)
-	return new(mod.Int).Init(rq, Q), nil
-
+	return BN254.Rand()
 }
 
 // Init initializes a polynomial with the given coefficients
@@ -43,14 +33,28 @@ func (p *Polynomial) InitFromCopy() *Polynomial {
 	return &Polynomial{r[:], p.Degree}
 }
 
+// InitFromZerosArray creates the polynomial of n zero coefficients over BN254
 func (p *Polynomial) InitFromZerosArray(n int) *Polynomial {
+	return p.InitFromZerosArrayOver(BN254, n)
+}
+
+// InitFromZerosArrayOver creates the polynomial of n zero coefficients over f
+func (p *Polynomial) InitFromZerosArrayOver(f Field, n int) *Polynomial {
 	r := make([]*mod.Int, n)
 	for i := 0; i < n; i++ {
-		r[i] = new(mod.Int).Init(big.NewInt(int64(0)), Q)
+		r[i] = f.NewElement(0)
 	}
 	return &Polynomial{r[:], n}
 }
 
+// Field returns the field of the coefficients, BN254 for an empty polynomial
+func (p *Polynomial) Field() Field {
+	if p.Degree == 0 {
+		return BN254
+	}
+	return FieldOf(p.Coefficient[0].M)
+}
+
 // newPolZeroAt generates a new polynomial that has value zero at the given value
 func (p *Polynomial) InitPolZeroAt(pointPos, totalPoints int, height *mod.Int) *Polynomial {
 	fac := 1
@@ -59,13 +63,13 @@ func (p *Polynomial) InitPolZeroAt(pointPos, totalPoints int, height *mod.Int) *
 			fac = fac * (pointPos - i)
 		}
 	}
-	facBig := new(mod.Int).Init(big.NewInt(int64(fac)), Q)
+	facBig := new(mod.Int).Init(big.NewInt(int64(fac)), height.M)
 	hf := new(mod.Int).Div(height, facBig)
 	r := new(Polynomial).Init([]*mod.Int{hf.(*mod.Int)})
 	for i := 1; i < totalPoints+1; i++ {
 		if i != pointPos {
-			ineg := new(mod.Int).Init(big.NewInt(int64(-i)), Q)
-			b1 := new(mod.Int).Init(big.NewInt(int64(1)), Q)
+			ineg := new(mod.Int).Init(big.NewInt(int64(-i)), height.M)
+			b1 := new(mod.Int).Init(big.NewInt(int64(1)), height.M)
 			r = p.Mul(r, &Polynomial{[]*mod.Int{ineg, b1}, 2})
 		}
 	}
@@ -77,10 +81,20 @@ func (p *Polynomial) SetCoefficient(coef []*mod.Int) {
 	p.Degree = len(coef)
 }
 
+// fieldOf returns the field of the first non empty polynomial
+func fieldOf(ps ...*Polynomial) Field {
+	for _, p := range ps {
+		if p.Degree > 0 {
+			return p.Field()
+		}
+	}
+	return BN254
+}
+
 // polynomial operation.
 
 func (p *Polynomial) Add(a, b *Polynomial) *Polynomial {
-	p = p.InitFromZerosArray(max(a.Degree, b.Degree))
+	p = p.InitFromZerosArrayOver(fieldOf(a, b), max(a.Degree, b.Degree))
 	for i := 0; i < a.Degree; i++ {
 		p.Coefficient[i] = new(mod.Int).Add(p.Coefficient[i], a.Coefficient[i]).(*mod.Int)
 	}
@@ -91,7 +105,7 @@ func (p *Polynomial) Add(a, b *Polynomial) *Polynomial {
 }
 
 func (p *Polynomial) Sub(a, b *Polynomial) *Polynomial {
-	p = p.InitFromZerosArray(max(a.Degree, b.Degree))
+	p = p.InitFromZerosArrayOver(fieldOf(a, b), max(a.Degree, b.Degree))
 	for i := 0; i < a.Degree; i++ {
 		p.Coefficient[i] = new(mod.Int).Add(p.Coefficient[i], a.Coefficient[i]).(*mod.Int)
 	}
@@ -102,7 +116,12 @@ func (p *Polynomial) Sub(a, b *Polynomial) *Polynomial {
 }
 
 func (p *Polynomial) Mul(a, b *Polynomial) *Polynomial {
-	p = new(Polynomial).InitFromZerosArray(a.Degree + b.Degree - 1)
+	if a.Degree >= nttMulThreshold && b.Degree >= nttMulThreshold {
+		if r, ok := mulNTT(a, b); ok {
+			return r
+		}
+	}
+	p = new(Polynomial).InitFromZerosArrayOver(fieldOf(a, b), a.Degree+b.Degree-1)
 	for i := 0; i < a.Degree; i++ {
 		for j := 0; j < b.Degree; j++ {
 			p.Coefficient[i+j] = new(mod.Int).Add(p.Coefficient[i+j], new(mod.Int).Mul(a.Coefficient[i], b.Coefficient[j])).(*mod.Int)
@@ -111,21 +130,128 @@ func (p *Polynomial) Mul(a, b *Polynomial) *Polynomial {
 	return p
 }
 
+// Div returns the quotient and the remainder of the division of a by b.
+// Large divisions are done in O(n log n) with a Newton inversion of the
+// reversed divisor, small ones with the schoolbook algorithm.
 func (p *Polynomial) Div(a, b *Polynomial) (*Polynomial, *Polynomial) {
+	b = b.trim()
+	if a.Degree < b.Degree {
+		return new(Polynomial).InitFromZerosArrayOver(fieldOf(a, b), 1), a.InitFromCopy()
+	}
+	if a.Degree-b.Degree+1 >= nttMulThreshold && b.Degree >= nttMulThreshold {
+		if q, r, ok := divNewton(a, b); ok {
+			return q, r
+		}
+	}
 	// https://en.wikipedia.org/wiki/Division_algorithm
-	p = new(Polynomial).InitFromZerosArray(a.Degree - b.Degree + 1)
+	p = new(Polynomial).InitFromZerosArrayOver(fieldOf(a, b), a.Degree-b.Degree+1)
 	rem := a.InitFromCopy()
-	for rem.Degree >= b.Degree {
-		l := new(mod.Int).Div(rem.Coefficient[rem.Degree-1], b.Coefficient[b.Degree-1]).(*mod.Int)
-		pos := rem.Degree - b.Degree
+	lead := new(mod.Int).Inv(b.Coefficient[b.Degree-1]).(*mod.Int)
+	for pos := a.Degree - b.Degree; pos >= 0; pos-- {
+		l := new(mod.Int).Mul(rem.Coefficient[pos+b.Degree-1], lead).(*mod.Int)
 		p.Coefficient[pos] = l
-		aux := new(Polynomial).InitFromZerosArray(pos)
-		aux1 := append(aux.Coefficient, l)
-		tempoly := new(Polynomial).Init(aux1)
-		aux2 := new(Polynomial).Sub(rem, new(Polynomial).Mul(b, tempoly))
-		rem.SetCoefficient(aux2.Coefficient[:aux2.Degree-1])
+		for j := 0; j < b.Degree; j++ {
+			rem.Coefficient[pos+j].Sub(rem.Coefficient[pos+j], new(mod.Int).Mul(l, b.Coefficient[j]))
+		}
+	}
+	return p, remainder(rem, b.Degree-1)
+}
+
+// remainder returns the n low coefficients of r, or the zero polynomial of
+// degree 0 when the divisor is a constant
+func remainder(r *Polynomial, n int) *Polynomial {
+	if n == 0 {
+		return new(Polynomial).InitFromZerosArrayOver(r.Field(), 1)
+	}
+	return &Polynomial{r.Coefficient[:n], n}
+}
+
+// divNewton computes the quotient as rev(q) = rev(a) · rev(b)⁻¹ mod xᵏ where
+// k = deg(a) - deg(b) + 1, and the remainder as r = a - q·b
+func divNewton(a, b *Polynomial) (*Polynomial, *Polynomial, bool) {
+	k := a.Degree - b.Degree + 1
+	revB := b.reverse()
+	inv, ok := revB.inverseMod(k)
+	if !ok {
+		return nil, nil, false
+	}
+	revQ := new(Polynomial).Mul(a.reverse().truncate(k), inv).truncate(k)
+	q := revQ.reverse()
+	r := new(Polynomial).Sub(a, new(Polynomial).Mul(q, b))
+	return q, remainder(r, b.Degree-1), true
+}
+
+// inverseMod returns g such that p·g = 1 mod xᵏ, using the Newton iteration
+// g ← g·(2 - p·g) which doubles the number of correct coefficients each step
+func (p *Polynomial) inverseMod(k int) (*Polynomial, bool) {
+	if !p.Coefficient[0].Nonzero() {
+		return nil, false
+	}
+	m := p.Coefficient[0].M
+	g := new(Polynomial).Init([]*mod.Int{new(mod.Int).Inv(p.Coefficient[0]).(*mod.Int)})
+	two := new(Polynomial).Init([]*mod.Int{mod.NewInt64(2, m)})
+	for l := 1; l < k; {
+		l <<= 1
+		pg := new(Polynomial).Mul(p.truncate(l), g).truncate(l)
+		g = new(Polynomial).Mul(g, new(Polynomial).Sub(two, pg)).truncate(l)
 	}
-	return p, rem
+	return g.truncate(k), true
+}
+
+// DivByLinear divides a by (x - z) with synthetic division in linear time,
+// returning the quotient and the remainder, which is a(z)
+func (p *Polynomial) DivByLinear(a *Polynomial, z *mod.Int) (*Polynomial, *mod.Int) {
+	if a.Degree <= 1 {
+		r := new(mod.Int).Init64(0, z.M)
+		if a.Degree == 1 {
+			r.Set(a.Coefficient[0])
+		}
+		return new(Polynomial).InitFromZerosArrayOver(FieldOf(z.M), 1), r
+	}
+	q := make([]*mod.Int, a.Degree-1)
+	acc := new(mod.Int).Set(a.Coefficient[a.Degree-1]).(*mod.Int)
+	for i := a.Degree - 2; i >= 0; i-- {
+		q[i] = acc
+		acc = new(mod.Int).Mul(acc, z).(*mod.Int)
+		acc.Add(acc, a.Coefficient[i])
+	}
+	return new(Polynomial).Init(q), acc
+}
+
+// IsZero returns true when all the coefficients are zero
+func (p *Polynomial) IsZero() bool {
+	for i := 0; i < p.Degree; i++ {
+		if p.Coefficient[i].Nonzero() {
+			return false
+		}
+	}
+	return true
+}
+
+// trim drops the zero coefficients of the highest degrees, keeping at least one
+func (p *Polynomial) trim() *Polynomial {
+	n := p.Degree
+	for n > 1 && !p.Coefficient[n-1].Nonzero() {
+		n--
+	}
+	return &Polynomial{p.Coefficient[:n], n}
+}
+
+// truncate returns p mod xᵏ
+func (p *Polynomial) truncate(k int) *Polynomial {
+	if p.Degree <= k {
+		return p
+	}
+	return &Polynomial{p.Coefficient[:k], k}
+}
+
+// reverse returns xⁿ⁻¹·p(1/x), i.e. the coefficients in reverse order
+func (p *Polynomial) reverse() *Polynomial {
+	r := make([]*mod.Int, p.Degree)
+	for i := 0; i < p.Degree; i++ {
+		r[i] = p.Coefficient[p.Degree-1-i]
+	}
+	return &Polynomial{r, p.Degree}
 }
 
 func (p *Polynomial) MulByConstant(a *Polynomial, c *mod.Int) *Polynomial {
@@ -141,13 +267,13 @@ func (p *Polynomial) DivByConstant(c *mod.Int) *Polynomial {
 	return p
 }
 
-// polynomialEval evaluates the polinomial over the Finite Field at the given value x
+// Eval evaluates the polinomial over the Finite Field at the given value x
+// using Horner's rule: p(x) = c₀ + x(c₁ + x(c₂ + ...))
 func (p *Polynomial) Eval(x *mod.Int) *mod.Int {
-	r := new(mod.Int).Init(big.NewInt(int64(0)), Q)
-	for i := 0; i < p.Degree; i++ {
-		xi := new(mod.Int).Exp(x, big.NewInt(int64(i)))
-		elem := new(mod.Int).Mul(p.Coefficient[i], xi)
-		r.Add(r, elem)
+	r := new(mod.Int).Init(big.NewInt(int64(0)), x.M)
+	for i := p.Degree - 1; i >= 0; i-- {
+		r.Mul(r, x)
+		r.Add(r, p.Coefficient[i])
 	}
 	return r
 }
@@ -182,45 +308,24 @@ func (p *Polynomial) ToString() string {
 // zeroPolynomial returns the zero polynomial:
 // z(x) = (x - z_0) (x - z_1) ... (x - z_{k-1})
 func (p *Polynomial) Zero(zs []*mod.Int) *Polynomial {
-	p = &Polynomial{[]*mod.Int{new(mod.Int).Neg(zs[0]).(*mod.Int), new(mod.Int).Init(big.NewInt(1), Q)}, 2} // (x - z0)
-	for i := 1; i < len(zs); i++ {
-		p = new(Polynomial).Mul(p, &Polynomial{[]*mod.Int{new(mod.Int).Neg(zs[i]).(*mod.Int), new(mod.Int).Init(big.NewInt(1), Q)}, 2}) // (x - zi)
+	t, err := NewSubproductTree(zs)
+	if err != nil {
+		return new(Polynomial).Init([]*mod.Int{BN254.NewElement(1)})
 	}
-	return p
+	return t.Root()
 }
 
 // LagrangeInterpolation implements the Lagrange interpolation:
 // https://en.wikipedia.org/wiki/Lagrange_polynomial
+// The basis polynomials are never built one by one, the interpolation is done
+// over a subproduct tree of the points (see SubproductTree.Interpolate).
 func (p *Polynomial) LagrangeInterpolation(x, y []*mod.Int) (*Polynomial, error) {
-	// p(x) will be the interpoled polynomial
-	// var p []*big.Int
 	if len(x) != len(y) {
 		return &Polynomial{nil, 0}, fmt.Errorf("len(x)!=len(y): %d, %d", len(x), len(y))
 	}
-	p = new(Polynomial).InitFromZerosArray(len(x))
-	k := len(x)
-
-	for j := 0; j < k; j++ {
-		// jPol is the Lagrange basis polynomial for each point
-		var jPol = new(Polynomial).InitFromZerosArray(0)
-		for m := 0; m < k; m++ {
-			// if x[m] == x[j] {
-			if m == j {
-				continue
-			}
-			// numerator & denominator of the current iteration
-			num := &Polynomial{[]*mod.Int{new(mod.Int).Neg(x[m]).(*mod.Int), new(mod.Int).Init(big.NewInt(1), Q)}, 2} // (x^1 - x_m)
-			den := new(mod.Int).Sub(x[j], x[m])                                                                       // x_j-x_m
-			mPol := num.DivByConstant(den.(*mod.Int))
-			if jPol.Degree == 0 {
-				// first j iteration
-				jPol = mPol
-				continue
-			}
-			jPol = new(Polynomial).Mul(jPol, mPol)
-		}
-		p = new(Polynomial).Add(p, new(Polynomial).MulByConstant(jPol, y[j]))
+	t, err := NewSubproductTree(x)
+	if err != nil {
+		return &Polynomial{nil, 0}, err
 	}
-
-	return p, nil
+	return t.Interpolate(y)
 }
@@ -108,6 +108,13 @@ func TestPolynomial_Div(t *testing.T) {
 	quo2, rem2 := new(Polynomial).Div(c, d)
 	assert.Equal(t, quo2, new(Polynomial).Init([]*mod.Int{b3, b1, b1}))
 	assert.Equal(t, rem2.Coefficient[0].Int64(), int64(5))
+
+	// a constant divisor leaves the zero polynomial of degree 0
+	quo3, rem3 := new(Polynomial).Div(c, new(Polynomial).Init([]*mod.Int{b2}))
+	assert.Equal(t, 1, rem3.Degree)
+	assert.True(t, rem3.IsZero())
+	assert.True(t, quo3.Coefficient[0].Equal(negf(b2)))
+	assert.True(t, quo3.Coefficient[3].Equal(new(mod.Int).Div(b1, b2)))
 }
 
 func TestPolynomial_InitPolZeroAt(t *testing.T) {
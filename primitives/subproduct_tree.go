@@ -0,0 +1,141 @@
+package primitives
+
+import (
+	"fmt"
+	"github.com/drand/kyber/group/mod"
+)
+
+// SubproductTree is the binary tree of the products of the linear factors
+// (x - x_i) over a set of points. The leaves hold (x - x_i) and every
+// internal node holds the product of its children, so the root is the
+// zero polynomial of all the points.
+// See "Modern Computer Algebra" (von zur Gathen, Gerhard), chapter 10.
+type SubproductTree struct {
+	Points []*mod.Int
+	root   *subproductNode
+}
+
+type subproductNode struct {
+	poly        *Polynomial
+	left, right *subproductNode
+	lo, hi      int // the node covers Points[lo:hi]
+}
+
+// NewSubproductTree builds the subproduct tree over the given points
+func NewSubproductTree(xs []*mod.Int) (*SubproductTree, error) {
+	if len(xs) == 0 {
+		return nil, fmt.Errorf("subproduct tree needs at least one point")
+	}
+	return &SubproductTree{xs, buildSubproductNode(xs, 0, len(xs))}, nil
+}
+
+func buildSubproductNode(xs []*mod.Int, lo, hi int) *subproductNode {
+	if hi-lo == 1 {
+		// (x - x_lo)
+		leaf := new(Polynomial).Init([]*mod.Int{new(mod.Int).Neg(xs[lo]).(*mod.Int), mod.NewInt64(1, xs[lo].M)})
+		return &subproductNode{poly: leaf, lo: lo, hi: hi}
+	}
+	mid := (lo + hi) / 2
+	l := buildSubproductNode(xs, lo, mid)
+	r := buildSubproductNode(xs, mid, hi)
+	return &subproductNode{poly: new(Polynomial).Mul(l.poly, r.poly), left: l, right: r, lo: lo, hi: hi}
+}
+
+// Root returns the zero polynomial z(x) = (x - x_0) (x - x_1) ... (x - x_{n-1})
+func (t *SubproductTree) Root() *Polynomial {
+	return t.root.poly
+}
+
+// Evaluate evaluates p at every point of the tree by reducing p modulo the
+// nodes of the tree from the root down to the leaves
+func (t *SubproductTree) Evaluate(p *Polynomial) []*mod.Int {
+	ys := make([]*mod.Int, len(t.Points))
+	t.evaluate(t.root, p, ys)
+	return ys
+}
+
+func (t *SubproductTree) evaluate(node *subproductNode, p *Polynomial, ys []*mod.Int) {
+	if node.hi-node.lo <= multiEvalHornerThreshold {
+		for i := node.lo; i < node.hi; i++ {
+			ys[i] = p.Eval(t.Points[i])
+		}
+		return
+	}
+	if p.Degree >= node.poly.Degree {
+		_, p = new(Polynomial).Div(p, node.poly)
+	}
+	t.evaluate(node.left, p, ys)
+	t.evaluate(node.right, p, ys)
+}
+
+// Interpolate returns the polynomial of degree < len(Points) that takes the
+// value ys[i] at Points[i]. The weights y_i / z'(x_i) are combined bottom-up:
+// N(node) = N(left)·z(right) + N(right)·z(left)
+func (t *SubproductTree) Interpolate(ys []*mod.Int) (*Polynomial, error) {
+	if len(ys) != len(t.Points) {
+		return nil, fmt.Errorf("len(x)!=len(y): %d, %d", len(t.Points), len(ys))
+	}
+	// z'(x_i) = ∏_{j≠i} (x_i - x_j)
+	dz := t.Evaluate(t.Root().Derivative())
+	ws := make([]*mod.Int, len(ys))
+	for i := range ys {
+		if !dz[i].Nonzero() {
+			return nil, fmt.Errorf("points must be distinct, x[%d] is repeated", i)
+		}
+		ws[i] = new(mod.Int).Div(ys[i], dz[i]).(*mod.Int)
+	}
+	p := t.combine(t.root, ws)
+	// the interpolated polynomial has exactly len(Points) coefficients
+	if p.Degree < len(ys) {
+		p = new(Polynomial).Add(p, new(Polynomial).InitFromZerosArrayOver(p.Field(), len(ys)))
+	}
+	return p, nil
+}
+
+func (t *SubproductTree) combine(node *subproductNode, ws []*mod.Int) *Polynomial {
+	if node.left == nil {
+		return new(Polynomial).Init([]*mod.Int{ws[node.lo]})
+	}
+	l := new(Polynomial).Mul(t.combine(node.left, ws), node.right.poly)
+	r := new(Polynomial).Mul(t.combine(node.right, ws), node.left.poly)
+	return new(Polynomial).Add(l, r)
+}
+
+// multiEvalHornerThreshold is the number of points below which evaluating
+// with Horner's rule is cheaper than descending the tree
+const multiEvalHornerThreshold = 8
+
+// Derivative returns the formal derivative of the polynomial
+func (p *Polynomial) Derivative() *Polynomial {
+	if p.Degree <= 1 {
+		return new(Polynomial).InitFromZerosArrayOver(p.Field(), 1)
+	}
+	r := make([]*mod.Int, p.Degree-1)
+	for i := 1; i < p.Degree; i++ {
+		r[i-1] = new(mod.Int).Mul(p.Coefficient[i], mod.NewInt64(int64(i), p.Coefficient[i].M)).(*mod.Int)
+	}
+	return new(Polynomial).Init(r)
+}
+
+// MultiEval evaluates the polynomial at all the given points using a
+// subproduct tree, in O(n log² n) field operations instead of O(n²)
+func (p *Polynomial) MultiEval(xs []*mod.Int) []*mod.Int {
+	if len(xs) == 0 {
+		return []*mod.Int{}
+	}
+	t, _ := NewSubproductTree(xs)
+	return t.Evaluate(p)
+}
+
+// BatchEval evaluates the polynomial at every point, evaluating directly with
+// Horner's rule for a handful of points and through a subproduct tree otherwise
+func (p *Polynomial) BatchEval(xs []*mod.Int) []*mod.Int {
+	if len(xs) > multiEvalHornerThreshold && p.Degree > multiEvalHornerThreshold {
+		return p.MultiEval(xs)
+	}
+	ys := make([]*mod.Int, len(xs))
+	for i := range xs {
+		ys[i] = p.Eval(xs[i])
+	}
+	return ys
+}
@@ -0,0 +1,65 @@
+package primitives
+
+import (
+	"github.com/drand/kyber/group/mod"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func randPolynomial(t testing.TB, n int) *Polynomial {
+	c, err := BN254.RandVector(n)
+	assert.Nil(t, err)
+	return new(Polynomial).Init(c)
+}
+
+func TestSubproductTree_Evaluate(t *testing.T) {
+	p := randPolynomial(t, 40)
+	xs := randPolynomial(t, 33).Coefficient
+
+	ys := p.MultiEval(xs)
+	for i := range xs {
+		assert.True(t, ys[i].Equal(p.Eval(xs[i])))
+	}
+	ys = p.BatchEval(xs[:3])
+	for i := range ys {
+		assert.True(t, ys[i].Equal(p.Eval(xs[i])))
+	}
+}
+
+func TestSubproductTree_Interpolate(t *testing.T) {
+	p := randPolynomial(t, 21)
+	xs := randPolynomial(t, 21).Coefficient
+	ys := p.MultiEval(xs)
+
+	tree, err := NewSubproductTree(xs)
+	assert.Nil(t, err)
+	i, err := tree.Interpolate(ys)
+	assert.Nil(t, err)
+	assert.Equal(t, p.Degree, i.Degree)
+	for k := 0; k < p.Degree; k++ {
+		assert.True(t, p.Coefficient[k].Equal(i.Coefficient[k]))
+	}
+	for k := range xs {
+		assert.True(t, tree.Root().Eval(xs[k]).Equal(mod.NewInt64(0, Q)))
+	}
+
+	// repeated points can not be interpolated
+	xs[3] = xs[5]
+	_, err = new(Polynomial).LagrangeInterpolation(xs, ys)
+	assert.NotNil(t, err)
+}
+
+func BenchmarkSubproductTree(b *testing.B) {
+	p := randPolynomial(b, 256)
+	xs := randPolynomial(b, 256).Coefficient
+	b.Run("MultiEval", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			p.MultiEval(xs)
+		}
+	})
+	b.Run("LagrangeInterpolation", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			new(Polynomial).LagrangeInterpolation(xs, p.Coefficient)
+		}
+	})
+}
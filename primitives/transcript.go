@@ -0,0 +1,67 @@
+package primitives
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"github.com/drand/kyber/group/mod"
+	"hash"
+	"math/big"
+)
+
+// Transcript is a Fiat-Shamir transcript: the prover and the verifier append
+// the same messages in the same order and derive the same challenges from
+// them, which makes an interactive public-coin protocol non interactive
+type Transcript struct {
+	h hash.Hash
+}
+
+// NewTranscript returns a transcript bound to the given protocol label
+func NewTranscript(label string) *Transcript {
+	t := &Transcript{sha256.New()}
+	t.AppendBytes("protocol", []byte(label))
+	return t
+}
+
+// AppendBytes absorbs a labelled message
+func (t *Transcript) AppendBytes(label string, b []byte) {
+	var l [8]byte
+	binary.BigEndian.PutUint64(l[:], uint64(len(label)))
+	t.h.Write(l[:])
+	t.h.Write([]byte(label))
+	binary.BigEndian.PutUint64(l[:], uint64(len(b)))
+	t.h.Write(l[:])
+	t.h.Write(b)
+}
+
+// AppendScalar absorbs a labelled field element
+func (t *Transcript) AppendScalar(label string, s *mod.Int) {
+	t.AppendBytes(label, s.V.Bytes())
+}
+
+// AppendScalars absorbs a labelled list of field elements
+func (t *Transcript) AppendScalars(label string, ss []*mod.Int) {
+	for _, s := range ss {
+		t.AppendScalar(label, s)
+	}
+}
+
+// AppendG1 absorbs a labelled point of 𝔾₁
+func (t *Transcript) AppendG1(label string, p G1) {
+	t.AppendBytes(label, p.Marshal())
+}
+
+// ChallengeBytes squeezes 32 bytes out of the transcript. The challenge is
+// absorbed back, so the following challenges depend on it.
+func (t *Transcript) ChallengeBytes(label string) []byte {
+	t.AppendBytes("challenge", []byte(label))
+	c := t.h.Sum(nil)
+	t.AppendBytes("challenge value", c)
+	return c
+}
+
+// ChallengeScalar squeezes a field element out of the transcript, 512 bits
+// are reduced modulo p so the challenge is close to uniform
+func (t *Transcript) ChallengeScalar(label string, f Field) *mod.Int {
+	c := append(t.ChallengeBytes(label), t.ChallengeBytes(label)...)
+	return f.NewElementFromBig(new(big.Int).SetBytes(c))
+}
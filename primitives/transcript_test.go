@@ -0,0 +1,28 @@
+package primitives
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTranscript_ChallengeScalar(t *testing.T) {
+	p := CurveBN254.G1Generator()
+	t1 := NewTranscript("test")
+	t1.AppendG1("commitment", p)
+	t1.AppendScalar("y", BN254.NewElement(35))
+	t2 := NewTranscript("test")
+	t2.AppendG1("commitment", p)
+	t2.AppendScalar("y", BN254.NewElement(35))
+
+	// same messages give the same challenges
+	c1 := t1.ChallengeScalar("gamma", BN254)
+	assert.True(t, c1.Equal(t2.ChallengeScalar("gamma", BN254)))
+	// consecutive challenges differ
+	assert.False(t, c1.Equal(t1.ChallengeScalar("gamma", BN254)))
+
+	// a different message gives a different challenge
+	t3 := NewTranscript("test")
+	t3.AppendG1("commitment", p)
+	t3.AppendScalar("y", BN254.NewElement(36))
+	assert.False(t, c1.Equal(t3.ChallengeScalar("gamma", BN254)))
+}
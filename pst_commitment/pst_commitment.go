@@ -0,0 +1,113 @@
+// Package pst_commitment implements the multilinear polynomial commitment of
+// Papamanthou, Shi and Tamassia (PST13), a multivariate KZG: a multilinear
+// polynomial f is committed as [f(t)]₁ for secret t ∈ Fⁿ, and f(z) = y is
+// proven by the commitments to the quotients qⱼ of
+//
+//	f(x) - f(z) = Σⱼ (xⱼ - zⱼ)·qⱼ(xⱼ₊₁, ..., xₙ₋₁)
+//
+// checked with n+1 pairings. https://eprint.iacr.org/2011/587
+package pst_commitment
+
+import (
+	"commitment/primitives"
+	"fmt"
+	"github.com/drand/kyber/group/mod"
+)
+
+// SRS is the structured reference string of multilinear polynomials of n
+// variables
+type SRS struct {
+	Curve primitives.Curve
+	N     int
+	// Lagrange[k] holds [eq(b, (tₖ, ..., tₙ₋₁))]₁ for b ∈ {0,1}ⁿ⁻ᵏ, the
+	// multilinear Lagrange basis in the last n-k variables
+	Lagrange [][]primitives.G1
+	T2       []primitives.G2 // [tⱼ]₂
+}
+
+// NewSRS returns a new reference string over BN254. This step should be done
+// in a secure & distributed way, t is toxic waste.
+func NewSRS(n int) (*SRS, error) {
+	return NewSRSOver(primitives.CurveBN254, n)
+}
+
+// NewSRSOver returns a new reference string over the given curve
+func NewSRSOver(curve primitives.Curve, n int) (*SRS, error) {
+	f := curve.ScalarField()
+	t := make([]*mod.Int, n)
+	for j := range t {
+		r, err := f.Rand()
+		if err != nil {
+			return nil, err
+		}
+		t[j] = r
+	}
+	g, h := curve.G1Generator(), curve.G2Generator()
+	srs := &SRS{Curve: curve, N: n, Lagrange: make([][]primitives.G1, n+1), T2: make([]primitives.G2, n)}
+	for k := 0; k <= n; k++ {
+		eq := primitives.EqPolynomial(f, t[k:])
+		srs.Lagrange[k] = make([]primitives.G1, len(eq.Evaluations))
+		for b, e := range eq.Evaluations {
+			srs.Lagrange[k][b] = g.ScalarMult(&e.V)
+		}
+	}
+	for j := range t {
+		srs.T2[j] = h.ScalarMult(&t[j].V)
+	}
+	return srs, nil
+}
+
+// Commit generates the commitment [f(t)]₁ = Σ_b f(b)·[eq(b, t)]₁
+func Commit(srs *SRS, f *primitives.MultilinearPoly) (primitives.G1, error) {
+	if f.NumVars != srs.N {
+		return nil, fmt.Errorf("the reference string is for %d variables, got %d", srs.N, f.NumVars)
+	}
+	return commitLevel(srs, 0, f.Evaluations), nil
+}
+
+func commitLevel(srs *SRS, k int, evals []*mod.Int) primitives.G1 {
+	return primitives.MultiScalarMultG1(srs.Curve, srs.Lagrange[k], primitives.Scalars(evals))
+}
+
+// EvaluationProof generates the proof of f(z) = y: the commitments
+// [qⱼ(tⱼ₊₁, ..., tₙ₋₁)]₁
+func EvaluationProof(srs *SRS, f *primitives.MultilinearPoly, z []*mod.Int, y *mod.Int) ([]primitives.G1, error) {
+	if f.NumVars != srs.N || len(z) != srs.N {
+		return nil, fmt.Errorf("the reference string is for %d variables, got %d and a point of %d", srs.N, f.NumVars, len(z))
+	}
+	proof := make([]primitives.G1, srs.N)
+	fj := f
+	for j := range proof {
+		// qⱼ(x) = fⱼ(1, x) - fⱼ(0, x)
+		q := make([]*mod.Int, len(fj.Evaluations)/2)
+		for i := range q {
+			q[i] = new(mod.Int).Sub(fj.Evaluations[2*i+1], fj.Evaluations[2*i]).(*mod.Int)
+		}
+		proof[j] = commitLevel(srs, j+1, q)
+		// fⱼ₊₁ = fⱼ(zⱼ, ...)
+		fj = fj.FixFirstVariable(z[j])
+	}
+	if !fj.Evaluations[0].Equal(y) {
+		return nil, fmt.Errorf("f(z) is %s, not %s", fj.Evaluations[0].String(), y.String())
+	}
+	return proof, nil
+}
+
+// Verify verifies the proof of f(z) = y for the commitment c:
+// e(c - [y]₁, H) == Πⱼ e(πⱼ, [tⱼ]₂ - [zⱼ]₂)
+func Verify(srs *SRS, c primitives.G1, proof []primitives.G1, z []*mod.Int, y *mod.Int) bool {
+	if len(proof) != srs.N || len(z) != srs.N {
+		return false
+	}
+	h := srs.Curve.G2Generator()
+	// c - [y]₁
+	cy := c.Add(srs.Curve.G1Generator().ScalarMult(&y.V).Neg())
+
+	a := []primitives.G1{cy.Neg()}
+	b := []primitives.G2{h}
+	for j := range proof {
+		a = append(a, proof[j])
+		b = append(b, srs.T2[j].Add(h.ScalarMult(&z[j].V).Neg()))
+	}
+	return primitives.PairingCheck(a, b)
+}
@@ -0,0 +1,55 @@
+package pst_commitment
+
+import (
+	"commitment/primitives"
+	"github.com/drand/kyber/group/mod"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSimpleFlow(t *testing.T) {
+	for _, curve := range []primitives.Curve{primitives.CurveBN254, primitives.CurveBLS12381} {
+		f := curve.ScalarField()
+		srs, err := NewSRSOver(curve, 3)
+		assert.Nil(t, err)
+		evals, err := f.RandVector(8)
+		assert.Nil(t, err)
+		p, err := primitives.NewMultilinearPoly(evals)
+		assert.Nil(t, err)
+		c, err := Commit(srs, p)
+		assert.Nil(t, err)
+
+		z, err := f.RandVector(3)
+		assert.Nil(t, err)
+		y, _ := p.Evaluate(z)
+		proof, err := EvaluationProof(srs, p, z, y)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(proof))
+		assert.True(t, Verify(srs, c, proof, z, y), curve.Name())
+
+		assert.False(t, Verify(srs, c, proof, z, z[0]), curve.Name())
+		assert.False(t, Verify(srs, c, proof, []*mod.Int{z[1], z[0], z[2]}, y), curve.Name())
+		assert.False(t, Verify(srs, c, proof[1:], z[1:], y), curve.Name())
+
+		_, err = EvaluationProof(srs, p, z, z[0])
+		assert.NotNil(t, err)
+	}
+}
+
+func TestCommit_HypercubePoint(t *testing.T) {
+	srs, err := NewSRS(2)
+	assert.Nil(t, err)
+	evals, err := primitives.BN254.RandVector(4)
+	assert.Nil(t, err)
+	p, _ := primitives.NewMultilinearPoly(evals)
+	c, _ := Commit(srs, p)
+
+	// on the hypercube the evaluation is the committed value
+	z := []*mod.Int{primitives.BN254.NewElement(1), primitives.BN254.NewElement(0)}
+	proof, err := EvaluationProof(srs, p, z, p.Evaluations[1])
+	assert.Nil(t, err)
+	assert.True(t, Verify(srs, c, proof, z, p.Evaluations[1]))
+
+	_, err = Commit(srs, &primitives.MultilinearPoly{Evaluations: p.Evaluations[:2], NumVars: 1})
+	assert.NotNil(t, err)
+}
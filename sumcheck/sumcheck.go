@@ -0,0 +1,173 @@
+// Package sumcheck implements the sumcheck protocol of Lund, Fortnow,
+// Karloff and Nisan for products of multilinear polynomials, made non
+// interactive with a Fiat–Shamir transcript. It reduces the claim
+//
+//	S = Σ_{b ∈ {0,1}ⁿ} f₀(b)·f₁(b)···f_{d-1}(b)
+//
+// to the claim f₀(r)···f_{d-1}(r) = S' at a random point r, to be discharged
+// by openings of the commitments to the fᵢ.
+package sumcheck
+
+import (
+	"commitment/primitives"
+	"fmt"
+	"github.com/drand/kyber/group/mod"
+)
+
+// Proof holds, for each round i, the evaluations at 0, 1, ..., d of the
+// univariate polynomial gᵢ(X) = Σ_b Πⱼ fⱼ(r₀, ..., rᵢ₋₁, X, b)
+type Proof struct {
+	RoundPolys [][]*mod.Int
+}
+
+// Sum returns Σ_b Πⱼ fⱼ(b), the claim proven by Prove
+func Sum(fs []*primitives.MultilinearPoly) (*mod.Int, error) {
+	if len(fs) == 0 {
+		return nil, fmt.Errorf("need at least one polynomial")
+	}
+	n := fs[0].NumVars
+	for _, fj := range fs {
+		if fj == nil || fj.NumVars != n || len(fj.Evaluations) != 1<<n {
+			return nil, fmt.Errorf("the polynomials must all have %d variables", n)
+		}
+	}
+	f := primitives.FieldOf(fs[0].Evaluations[0].M)
+	s := f.NewElement(0)
+	for b := range fs[0].Evaluations {
+		p := f.NewElement(1)
+		for _, fj := range fs {
+			p = new(mod.Int).Mul(p, fj.Evaluations[b]).(*mod.Int)
+		}
+		s = new(mod.Int).Add(s, p).(*mod.Int)
+	}
+	return s, nil
+}
+
+// Prove proves that the sum of the product of the fs over the hypercube is
+// claim. It returns the proof, the random point r and the evaluations fⱼ(r).
+func Prove(fs []*primitives.MultilinearPoly, claim *mod.Int, tr *primitives.Transcript) (*Proof, []*mod.Int, []*mod.Int, error) {
+	s, err := Sum(fs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if claim == nil || !s.Equal(claim) {
+		return nil, nil, nil, fmt.Errorf("the sum is %s, not the claim", s.String())
+	}
+	field := primitives.FieldOf(claim.M)
+	n, d := fs[0].NumVars, len(fs)
+	bindShape(tr, field, n, d)
+	tr.AppendScalar("claim", claim)
+
+	proof := &Proof{make([][]*mod.Int, n)}
+	point := make([]*mod.Int, n)
+	tables := append([]*primitives.MultilinearPoly{}, fs...)
+	for i := 0; i < n; i++ {
+		// gᵢ(t) = Σ_b Πⱼ (fⱼ(0, b) + t·(fⱼ(1, b) - fⱼ(0, b)))
+		g := make([]*mod.Int, d+1)
+		half := len(tables[0].Evaluations) / 2
+		for t := range g {
+			g[t] = field.NewElement(0)
+			x := field.NewElement(int64(t))
+			for b := 0; b < half; b++ {
+				p := field.NewElement(1)
+				for _, fj := range tables {
+					lo, hi := fj.Evaluations[2*b], fj.Evaluations[2*b+1]
+					p = new(mod.Int).Mul(p, new(mod.Int).Add(lo, new(mod.Int).Mul(x, new(mod.Int).Sub(hi, lo)))).(*mod.Int)
+				}
+				g[t] = new(mod.Int).Add(g[t], p).(*mod.Int)
+			}
+		}
+		proof.RoundPolys[i] = g
+		tr.AppendScalars("round", g)
+		point[i] = tr.ChallengeScalar("r", field)
+		for j := range tables {
+			tables[j] = tables[j].FixFirstVariable(point[i])
+		}
+	}
+	evals := make([]*mod.Int, d)
+	for j := range tables {
+		evals[j] = tables[j].Evaluations[0]
+	}
+	return proof, point, evals, nil
+}
+
+// Verify verifies the proof that the sum over the hypercube of a product of
+// degree multilinear polynomials of numVars variables is claim. It returns
+// the random point r and the claimed value of Πⱼ fⱼ(r), which the caller
+// must check against the polynomials.
+func Verify(numVars, degree int, claim *mod.Int, proof *Proof, tr *primitives.Transcript) ([]*mod.Int, *mod.Int, error) {
+	if claim == nil || proof == nil {
+		return nil, nil, fmt.Errorf("missing claim or proof")
+	}
+	if len(proof.RoundPolys) != numVars {
+		return nil, nil, fmt.Errorf("expected %d rounds, got %d", numVars, len(proof.RoundPolys))
+	}
+	field := primitives.FieldOf(claim.M)
+	bindShape(tr, field, numVars, degree)
+	tr.AppendScalar("claim", claim)
+	point := make([]*mod.Int, numVars)
+	for i, g := range proof.RoundPolys {
+		if len(g) != degree+1 {
+			return nil, nil, fmt.Errorf("round %d: expected %d evaluations, got %d", i, degree+1, len(g))
+		}
+		for _, e := range g {
+			if e == nil {
+				return nil, nil, fmt.Errorf("round %d: missing evaluation", i)
+			}
+		}
+		// gᵢ(0) + gᵢ(1) = the previous claim
+		if !new(mod.Int).Add(g[0], g[1]).Equal(claim) {
+			return nil, nil, fmt.Errorf("round %d: g(0) + g(1) is not the claim", i)
+		}
+		tr.AppendScalars("round", g)
+		point[i] = tr.ChallengeScalar("r", field)
+		claim = interpolate(field, g, point[i])
+	}
+	return point, claim, nil
+}
+
+// VerifyEvaluations verifies the proof and that the evaluations fⱼ(r),
+// opened from commitments, match the final claim
+func VerifyEvaluations(numVars int, claim *mod.Int, proof *Proof, evals []*mod.Int, tr *primitives.Transcript) ([]*mod.Int, error) {
+	point, final, err := Verify(numVars, len(evals), claim, proof, tr)
+	if err != nil {
+		return nil, err
+	}
+	p := primitives.FieldOf(claim.M).NewElement(1)
+	for _, e := range evals {
+		if e == nil {
+			return nil, fmt.Errorf("missing evaluation")
+		}
+		p = new(mod.Int).Mul(p, e).(*mod.Int)
+	}
+	if !p.Equal(final) {
+		return nil, fmt.Errorf("the product of the evaluations is not the final claim")
+	}
+	return point, nil
+}
+
+// bindShape appends the number of variables and the degree to the
+// transcript, so a proof cannot be replayed for another shape
+func bindShape(tr *primitives.Transcript, field primitives.Field, numVars, degree int) {
+	tr.AppendScalar("num vars", field.NewElement(int64(numVars)))
+	tr.AppendScalar("degree", field.NewElement(int64(degree)))
+}
+
+// interpolate evaluates at r the polynomial of degree len(g)-1 with
+// evaluations g at 0, 1, ..., len(g)-1
+func interpolate(field primitives.Field, g []*mod.Int, r *mod.Int) *mod.Int {
+	s := field.NewElement(0)
+	for i := range g {
+		// Lᵢ(r) = Π_{j≠i} (r - j)/(i - j)
+		num, den := field.NewElement(1), field.NewElement(1)
+		for j := range g {
+			if j == i {
+				continue
+			}
+			num = new(mod.Int).Mul(num, new(mod.Int).Sub(r, field.NewElement(int64(j)))).(*mod.Int)
+			den = new(mod.Int).Mul(den, field.NewElement(int64(i-j))).(*mod.Int)
+		}
+		s = new(mod.Int).Add(s, new(mod.Int).Mul(g[i], new(mod.Int).Div(num, den))).(*mod.Int)
+	}
+	return s
+}
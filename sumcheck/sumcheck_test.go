@@ -0,0 +1,76 @@
+package sumcheck
+
+import (
+	"commitment/primitives"
+	"github.com/drand/kyber/group/mod"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func randMultilinear(t *testing.T, n int) *primitives.MultilinearPoly {
+	evals, err := primitives.BN254.RandVector(1 << n)
+	assert.Nil(t, err)
+	p, err := primitives.NewMultilinearPoly(evals)
+	assert.Nil(t, err)
+	return p
+}
+
+func TestSumcheck(t *testing.T) {
+	for _, d := range []int{1, 2, 3} {
+		fs := make([]*primitives.MultilinearPoly, d)
+		for j := range fs {
+			fs[j] = randMultilinear(t, 4)
+		}
+		claim, err := Sum(fs)
+		assert.Nil(t, err)
+		proof, point, evals, err := Prove(fs, claim, primitives.NewTranscript("test"))
+		assert.Nil(t, err)
+
+		r, err := VerifyEvaluations(4, claim, proof, evals, primitives.NewTranscript("test"))
+		assert.Nil(t, err, d)
+		assert.Equal(t, point, r)
+		// the evaluations are the ones of the polynomials at r
+		for j := range fs {
+			e, _ := fs[j].Evaluate(r)
+			assert.True(t, e.Equal(evals[j]))
+		}
+
+		wrong := new(mod.Int).Add(claim, primitives.BN254.NewElement(1)).(*mod.Int)
+		_, err = VerifyEvaluations(4, wrong, proof, evals, primitives.NewTranscript("test"))
+		assert.NotNil(t, err, d)
+		_, err = VerifyEvaluations(4, claim, proof, append([]*mod.Int{wrong}, evals[1:]...), primitives.NewTranscript("test"))
+		assert.NotNil(t, err, d)
+
+		_, _, _, err = Prove(fs, wrong, primitives.NewTranscript("test"))
+		assert.NotNil(t, err)
+	}
+}
+
+func TestMalformed(t *testing.T) {
+	_, err := Sum(nil)
+	assert.NotNil(t, err)
+	_, err = Sum([]*primitives.MultilinearPoly{randMultilinear(t, 2), randMultilinear(t, 3)})
+	assert.NotNil(t, err)
+	_, _, _, err = Prove(nil, primitives.BN254.NewElement(0), primitives.NewTranscript("test"))
+	assert.NotNil(t, err)
+
+	fs := []*primitives.MultilinearPoly{randMultilinear(t, 2), randMultilinear(t, 2)}
+	claim, _ := Sum(fs)
+	proof, _, evals, err := Prove(fs, claim, primitives.NewTranscript("test"))
+	assert.Nil(t, err)
+	_, err = VerifyEvaluations(2, claim, nil, evals, primitives.NewTranscript("test"))
+	assert.NotNil(t, err)
+	_, err = VerifyEvaluations(2, nil, proof, evals, primitives.NewTranscript("test"))
+	assert.NotNil(t, err)
+	_, err = VerifyEvaluations(2, claim, proof, []*mod.Int{evals[0], nil}, primitives.NewTranscript("test"))
+	assert.NotNil(t, err)
+	proof.RoundPolys[1][2] = nil
+	_, err = VerifyEvaluations(2, claim, proof, evals, primitives.NewTranscript("test"))
+	assert.NotNil(t, err)
+}
+
+func TestInterpolate(t *testing.T) {
+	// g(X) = X² + 1
+	g := []*mod.Int{primitives.BN254.NewElement(1), primitives.BN254.NewElement(2), primitives.BN254.NewElement(5)}
+	assert.True(t, interpolate(primitives.BN254, g, primitives.BN254.NewElement(10)).Equal(primitives.BN254.NewElement(101)))
+}
@@ -0,0 +1,218 @@
+package commitment
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+//
+// Timed commitments: commitments which anybody can force open after T
+// sequential squarings in an RSA group (Rivest, Shamir and Wagner time-lock
+// puzzles, Boneh and Naor timed commitments)
+//
+
+// TimeLockParams is the time-lock puzzle of T squarings modulo N, with the
+// proof of Wesolowski that H = G^{2^T}. The factorization of N is the toxic
+// waste of the setup: whoever knows it computes H fast and can forge the
+// proof, so it is discarded by NewTimeLockParams.
+// https://eprint.iacr.org/2018/623
+type TimeLockParams struct {
+	N, G, H *big.Int
+	T       uint64
+	Proof   *big.Int // π = G^{⌊2^T/ℓ⌋}
+}
+
+// NewTimeLockParams generates a modulus of bits bits and the puzzle of t
+// squarings, with φ(N) to compute H fast
+func NewTimeLockParams(bits int, t uint64) (*TimeLockParams, error) {
+	if bits < 512 || t == 0 {
+		return nil, fmt.Errorf("need a modulus of at least 512 bits and t > 0, got %d and %d", bits, t)
+	}
+	p, err := rand.Prime(rand.Reader, bits/2)
+	if err != nil {
+		return nil, err
+	}
+	q, err := rand.Prime(rand.Reader, bits-bits/2)
+	if err != nil {
+		return nil, err
+	}
+	n := new(big.Int).Mul(p, q)
+	phi := new(big.Int).Mul(new(big.Int).Sub(p, big.NewInt(1)), new(big.Int).Sub(q, big.NewInt(1)))
+	// a random square
+	h, err := rand.Int(rand.Reader, n)
+	if err != nil {
+		return nil, err
+	}
+	g := new(big.Int).Exp(h, big.NewInt(2), n)
+
+	// G^{2^T} = G^{2^T mod φ(N)}
+	params := &TimeLockParams{N: n, G: g, T: t}
+	params.H = new(big.Int).Exp(g, new(big.Int).Exp(big.NewInt(2), new(big.Int).SetUint64(t), phi), n)
+	// π = G^{⌊2^T/ℓ⌋ mod φ(N)}, with ⌊2^T/ℓ⌋ mod φ(N) computed without 2^T
+	// as (2^T mod ℓφ(N) - 2^T mod ℓ)/ℓ
+	l := params.prime()
+	e := new(big.Int).SetUint64(t)
+	quotient := new(big.Int).Exp(big.NewInt(2), e, new(big.Int).Mul(l, phi))
+	quotient.Sub(quotient, new(big.Int).Exp(big.NewInt(2), e, l))
+	quotient.Div(quotient, l)
+	params.Proof = new(big.Int).Exp(g, quotient, n)
+	return params, nil
+}
+
+// Verify verifies the proof of Wesolowski: π^ℓ·G^r = H with r = 2^T mod ℓ
+func (p *TimeLockParams) Verify() bool {
+	if p.N == nil || p.N.Sign() <= 0 || !inGroup(p.N, p.G) || p.G.Cmp(big.NewInt(1)) == 0 || !inGroup(p.N, p.H) || !inGroup(p.N, p.Proof) {
+		return false
+	}
+	l := p.prime()
+	r := new(big.Int).Exp(big.NewInt(2), new(big.Int).SetUint64(p.T), l)
+	lhs := new(big.Int).Exp(p.Proof, l, p.N)
+	lhs.Mul(lhs, new(big.Int).Exp(p.G, r, p.N)).Mod(lhs, p.N)
+	return lhs.Cmp(p.H) == 0
+}
+
+// prime hashes the puzzle to a prime ℓ of 128 bits
+func (p *TimeLockParams) prime() *big.Int {
+	for i := uint64(0); ; i++ {
+		h := sha256.New()
+		h.Write([]byte("timed commitment: prime"))
+		writeInts(h, p.N, p.G, p.H)
+		var b [16]byte
+		binary.BigEndian.PutUint64(b[:8], p.T)
+		binary.BigEndian.PutUint64(b[8:], i)
+		h.Write(b[:])
+		l := new(big.Int).SetBytes(h.Sum(nil)[:16])
+		l.SetBit(l, 127, 1)
+		l.SetBit(l, 0, 1)
+		if l.ProbablyPrime(20) {
+			return l
+		}
+	}
+}
+
+// TimedCommitment commits to x by encrypting it under the key
+// K = A^{2^T} = H^a, which the committer computes fast and anybody else with
+// T squarings of A (Boneh and Naor). K is fixed by A, so the value opened
+// by the committer with a, and the value forced open, are the same. The
+// committer proves the knowledge of a with A = G^a: (R, Z) with
+// G^Z = R·A^e, so that they can open the commitment.
+type TimedCommitment struct {
+	A          *big.Int
+	Ciphertext []byte
+	R, Z       *big.Int
+}
+
+// TimedCommiter commits with the time-lock puzzle of the params
+type TimedCommiter struct {
+	Params *TimeLockParams
+}
+
+// NewTimedCommiter returns a commiter on the params, which must be verified
+func NewTimedCommiter(params *TimeLockParams) (*TimedCommiter, error) {
+	if !params.Verify() {
+		return nil, fmt.Errorf("invalid time-lock params")
+	}
+	return &TimedCommiter{Params: params}, nil
+}
+
+// Commit commits to x and returns the commitment with the exponent a to
+// open it
+func (tc *TimedCommiter) Commit(x []byte) (*TimedCommitment, *big.Int, error) {
+	p := tc.Params
+	// the exponents are 128 bits longer than N to be statistically uniform
+	bound := new(big.Int).Lsh(big.NewInt(1), uint(p.N.BitLen()+128))
+	a, err := rand.Int(rand.Reader, bound)
+	if err != nil {
+		return nil, nil, err
+	}
+	c := &TimedCommitment{A: new(big.Int).Exp(p.G, a, p.N)}
+	c.Ciphertext = xorKey(p.N, new(big.Int).Exp(p.H, a, p.N), x)
+
+	// Schnorr proof of knowledge of a, with an integer response
+	k, err := rand.Int(rand.Reader, new(big.Int).Lsh(bound, 128+128))
+	if err != nil {
+		return nil, nil, err
+	}
+	c.R = new(big.Int).Exp(p.G, k, p.N)
+	c.Z = new(big.Int).Add(k, new(big.Int).Mul(tc.challenge(c), a))
+	return c, a, nil
+}
+
+// Verify checks the regular opening of c to x with the exponent a: A = G^a
+// and the ciphertext decrypts to x under H^a = A^{2^T}
+func (tc *TimedCommiter) Verify(x []byte, a *big.Int, c *TimedCommitment) bool {
+	p := tc.Params
+	if c == nil || a == nil || a.Sign() < 0 || !inGroup(p.N, c.A) || new(big.Int).Exp(p.G, a, p.N).Cmp(c.A) != 0 {
+		return false
+	}
+	return bytes.Equal(xorKey(p.N, new(big.Int).Exp(p.H, a, p.N), c.Ciphertext), x)
+}
+
+// VerifyWellFormed verifies the proof that the committer knows the
+// exponent of A, and so can open c without the T squarings
+func (tc *TimedCommiter) VerifyWellFormed(c *TimedCommitment) bool {
+	p := tc.Params
+	if c == nil || !inGroup(p.N, c.A) || !inGroup(p.N, c.R) || c.Z == nil || c.Z.Sign() < 0 {
+		return false
+	}
+	lhs := new(big.Int).Exp(p.G, c.Z, p.N)
+	rhs := new(big.Int).Exp(c.A, tc.challenge(c), p.N)
+	rhs.Mul(rhs, c.R).Mod(rhs, p.N)
+	return lhs.Cmp(rhs) == 0
+}
+
+// ForceOpen opens c without the committer, with T sequential squarings of
+// A, and returns the committed value
+func (tc *TimedCommiter) ForceOpen(c *TimedCommitment) ([]byte, error) {
+	p := tc.Params
+	if c == nil || !inGroup(p.N, c.A) {
+		return nil, fmt.Errorf("A is not in the group")
+	}
+	key := new(big.Int).Set(c.A)
+	for i := uint64(0); i < p.T; i++ {
+		key.Mul(key, key).Mod(key, p.N)
+	}
+	return xorKey(p.N, key, c.Ciphertext), nil
+}
+
+func (tc *TimedCommiter) challenge(c *TimedCommitment) *big.Int {
+	h := sha256.New()
+	h.Write([]byte("timed commitment: knowledge of a"))
+	writeInts(h, tc.Params.N, tc.Params.G, tc.Params.H, c.A, c.R)
+	h.Write(c.Ciphertext)
+	return new(big.Int).SetBytes(h.Sum(nil)[:16])
+}
+
+// xorKey returns b ⊕ KDF(key), with SHA-256 in counter mode as the KDF
+func xorKey(n, key *big.Int, b []byte) []byte {
+	r := make([]byte, len(b))
+	kb := key.FillBytes(make([]byte, (n.BitLen()+7)/8))
+	for i := 0; i*sha256.Size < len(b); i++ {
+		var ctr [8]byte
+		binary.BigEndian.PutUint64(ctr[:], uint64(i))
+		block := sha256.Sum256(append(append([]byte("timed commitment: key"), kb...), ctr[:]...))
+		for j := 0; j < sha256.Size && i*sha256.Size+j < len(b); j++ {
+			r[i*sha256.Size+j] = b[i*sha256.Size+j] ^ block[j]
+		}
+	}
+	return r
+}
+
+// inGroup checks that x is a unit of ℤ_N
+func inGroup(n, x *big.Int) bool {
+	return x != nil && x.Sign() > 0 && x.Cmp(n) < 0 && new(big.Int).GCD(nil, nil, x, n).Cmp(big.NewInt(1)) == 0
+}
+
+func writeInts(h io.Writer, xs ...*big.Int) {
+	for _, x := range xs {
+		var l [8]byte
+		binary.BigEndian.PutUint64(l[:], uint64(len(x.Bytes())))
+		h.Write(l[:])
+		h.Write(x.Bytes())
+	}
+}
@@ -0,0 +1,112 @@
+package commitment
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+func TestTimeLockParams(t *testing.T) {
+	params, err := NewTimeLockParams(512, 1000)
+	assert.Nil(t, err)
+	assert.True(t, params.Verify())
+
+	// H = G^{2^T}
+	h := new(big.Int).Set(params.G)
+	for i := 0; i < 1000; i++ {
+		h.Mul(h, h).Mod(h, params.N)
+	}
+	assert.Equal(t, h, params.H)
+
+	wrong := *params
+	wrong.T = 999
+	assert.False(t, wrong.Verify())
+	wrong = *params
+	wrong.H = new(big.Int).Mul(params.H, params.G)
+	wrong.H.Mod(wrong.H, params.N)
+	assert.False(t, wrong.Verify())
+
+	_, err = NewTimeLockParams(256, 1000)
+	assert.NotNil(t, err)
+
+	// the setup does not compute 2^T, whose size is linear in T
+	huge, err := NewTimeLockParams(512, 1<<40)
+	assert.Nil(t, err)
+	assert.True(t, huge.Verify())
+}
+
+func TestTimedCommitment(t *testing.T) {
+	params, err := NewTimeLockParams(512, 1000)
+	assert.Nil(t, err)
+	tc, err := NewTimedCommiter(params)
+	assert.Nil(t, err)
+
+	x := []byte("a sealed bid which is longer than one block of the KDF")
+	c, a, err := tc.Commit(x)
+	assert.Nil(t, err)
+	assert.True(t, tc.VerifyWellFormed(c))
+	assert.True(t, tc.Verify(x, a, c))
+	assert.False(t, tc.Verify([]byte("another bid"), a, c))
+	assert.False(t, tc.Verify(x, new(big.Int).Add(a, big.NewInt(1)), c))
+
+	// the committer refuses to open
+	fx, err := tc.ForceOpen(c)
+	assert.Nil(t, err)
+	assert.Equal(t, x, fx)
+}
+
+func TestMaliciousTimedCommitment(t *testing.T) {
+	params, err := NewTimeLockParams(512, 100)
+	assert.Nil(t, err)
+	tc, err := NewTimedCommiter(params)
+	assert.Nil(t, err)
+	x := []byte("x")
+	c, a, err := tc.Commit(x)
+	assert.Nil(t, err)
+
+	// the committer encrypts x under another key than H^a, with an honest
+	// proof of knowledge of a: the commitment opens to the value forced
+	// open, not to x
+	bad := &TimedCommitment{A: c.A, Ciphertext: xorKey(params.N, big.NewInt(2), x)}
+	k := big.NewInt(12345)
+	bad.R = new(big.Int).Exp(params.G, k, params.N)
+	bad.Z = new(big.Int).Add(k, new(big.Int).Mul(tc.challenge(bad), a))
+	assert.True(t, tc.VerifyWellFormed(bad))
+	forced, err := tc.ForceOpen(bad)
+	assert.Nil(t, err)
+	assert.NotEqual(t, x, forced)
+	assert.False(t, tc.Verify(x, a, bad))
+	assert.True(t, tc.Verify(forced, a, bad))
+}
+
+func TestMalformedTimedCommitment(t *testing.T) {
+	params, err := NewTimeLockParams(512, 100)
+	assert.Nil(t, err)
+	tc, err := NewTimedCommiter(params)
+	assert.Nil(t, err)
+	c, _, err := tc.Commit([]byte("x"))
+	assert.Nil(t, err)
+
+	// the proof is bound to the ciphertext
+	bad := *c
+	bad.Ciphertext = append([]byte{}, c.Ciphertext...)
+	bad.Ciphertext[0] ^= 1
+	assert.False(t, tc.VerifyWellFormed(&bad))
+
+	bad = *c
+	bad.Z = new(big.Int).Add(c.Z, big.NewInt(1))
+	assert.False(t, tc.VerifyWellFormed(&bad))
+	bad.Z = nil
+	assert.False(t, tc.VerifyWellFormed(&bad))
+	bad = *c
+	bad.A = nil
+	assert.False(t, tc.VerifyWellFormed(&bad))
+	_, err = tc.ForceOpen(&bad)
+	assert.NotNil(t, err)
+	assert.False(t, tc.VerifyWellFormed(nil))
+
+	wrong := *params
+	wrong.T = 101
+	_, err = NewTimedCommiter(&wrong)
+	assert.NotNil(t, err)
+}
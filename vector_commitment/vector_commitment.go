@@ -0,0 +1,228 @@
+// Package vector_commitment commits to vectors of field elements with KZG:
+// the vector v is the polynomial p(x) with p(ωⁱ) = vᵢ over the n-th roots of
+// unity, committed with the setup in Lagrange form. Positions are opened with
+// KZG evaluation proofs at ωⁱ, and with the update keys of
+// https://eprint.iacr.org/2020/527 a change of one element updates the
+// commitment and every proof in O(1) group operations.
+package vector_commitment
+
+import (
+	"commitment/Polynomial_commitment"
+	"commitment/primitives"
+	"fmt"
+	"github.com/drand/kyber/group/mod"
+)
+
+// VectorCommitment commits to vectors of n field elements
+type VectorCommitment struct {
+	ts       *Polynomial_commitment.TrustedSetup
+	n        int
+	domain   []*mod.Int      // ωⁱ
+	lagrange []primitives.G1 // [Lᵢ(t)]₁
+	field    primitives.Field
+
+	// update keys, see PrecomputeUpdateKeys
+	a []primitives.G1 // [A(t)/(t - ωⁱ)]₁
+	u []primitives.G1 // [(Lᵢ(t) - 1)/(t - ωⁱ)]₁
+}
+
+// NewVectorCommitment prepares the commitments to vectors of n elements, n a
+// power of two not bigger than the trusted setup
+func NewVectorCommitment(ts *Polynomial_commitment.TrustedSetup, n int) (*VectorCommitment, error) {
+	f := ts.Curve.ScalarField()
+	omega, err := f.RootOfUnity(n)
+	if err != nil {
+		return nil, err
+	}
+	lagrange, err := ts.LagrangeBasis(n)
+	if err != nil {
+		return nil, err
+	}
+	domain := make([]*mod.Int, n)
+	domain[0] = f.NewElement(1)
+	for i := 1; i < n; i++ {
+		domain[i] = new(mod.Int).Mul(domain[i-1], omega).(*mod.Int)
+	}
+	return &VectorCommitment{ts: ts, n: n, domain: domain, lagrange: lagrange, field: f}, nil
+}
+
+// Point returns ωⁱ, the point of the domain where the element i is opened
+func (vc *VectorCommitment) Point(i int) (*mod.Int, error) {
+	if i < 0 || i >= vc.n {
+		return nil, fmt.Errorf("index %d out of range [0, %d)", i, vc.n)
+	}
+	return vc.domain[i], nil
+}
+
+// Commit returns the commitment Σ vᵢ·[Lᵢ(t)]₁
+func (vc *VectorCommitment) Commit(v []*mod.Int) (primitives.G1, error) {
+	if len(v) != vc.n {
+		return nil, fmt.Errorf("the vector has %d elements, expected %d", len(v), vc.n)
+	}
+	return primitives.MultiScalarMultG1(vc.ts.Curve, vc.lagrange, primitives.Scalars(v)), nil
+}
+
+// Open returns the proof of the element i of v
+func (vc *VectorCommitment) Open(v []*mod.Int, i int) (primitives.G1, error) {
+	if len(v) != vc.n {
+		return nil, fmt.Errorf("the vector has %d elements, expected %d", len(v), vc.n)
+	}
+	if i < 0 || i >= vc.n {
+		return nil, fmt.Errorf("index %d out of range [0, %d)", i, vc.n)
+	}
+	// q(x) = (p(x) - vᵢ)/(x - ωⁱ) in Lagrange form:
+	// q(ωʲ) = (vⱼ - vᵢ)/(ωʲ - ωⁱ) for j ≠ i
+	// q(ωⁱ) = Σ_{j≠i} (vⱼ - vᵢ)·ωʲ / (ωⁱ(ωⁱ - ωʲ))
+	q := make([]*mod.Int, vc.n)
+	qi := vc.field.NewElement(0)
+	for j := range q {
+		if j == i {
+			continue
+		}
+		d := new(mod.Int).Sub(v[j], v[i])
+		q[j] = new(mod.Int).Div(d, new(mod.Int).Sub(vc.domain[j], vc.domain[i])).(*mod.Int)
+		den := new(mod.Int).Mul(vc.domain[i], new(mod.Int).Sub(vc.domain[i], vc.domain[j]))
+		qi = new(mod.Int).Add(qi, new(mod.Int).Div(new(mod.Int).Mul(d, vc.domain[j]), den)).(*mod.Int)
+	}
+	q[i] = qi
+	return primitives.MultiScalarMultG1(vc.ts.Curve, vc.lagrange, primitives.Scalars(q)), nil
+}
+
+// Verify verifies the proof that the element i of the vector committed in c
+// is vi
+func (vc *VectorCommitment) Verify(c primitives.G1, i int, vi *mod.Int, proof primitives.G1) bool {
+	if i < 0 || i >= vc.n {
+		return false
+	}
+	return Polynomial_commitment.Verify(vc.ts, c, proof, vc.domain[i], vi)
+}
+
+// OpenMulti returns a single proof of the elements of v at the indexes is.
+// The setup needs len(is)+1 points of 𝔾₂ to verify it.
+func (vc *VectorCommitment) OpenMulti(v []*mod.Int, is []int) (primitives.G1, error) {
+	if len(v) != vc.n {
+		return nil, fmt.Errorf("the vector has %d elements, expected %d", len(v), vc.n)
+	}
+	zs, ys, err := vc.points(v, is)
+	if err != nil {
+		return nil, err
+	}
+	coefficients, err := primitives.InverseNTT(v)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := primitives.NewSubproductTree(zs)
+	if err != nil {
+		return nil, err
+	}
+	i, err := tree.Interpolate(ys)
+	if err != nil {
+		return nil, err
+	}
+	// q(x) = (p(x) - I(x)) / z(x)
+	p := new(primitives.Polynomial).Init(coefficients)
+	q, rem := new(primitives.Polynomial).Div(new(primitives.Polynomial).Sub(p, i), tree.Root())
+	if !rem.IsZero() {
+		return nil, fmt.Errorf("remainder should be 0, instead is %s", rem.ToString())
+	}
+	return Polynomial_commitment.Commit(vc.ts, q), nil
+}
+
+// VerifyMulti verifies the proof that the elements at the indexes is of the
+// vector committed in c are vs
+func (vc *VectorCommitment) VerifyMulti(c primitives.G1, is []int, vs []*mod.Int, proof primitives.G1) bool {
+	if len(is) != len(vs) || len(is)+1 > len(vc.ts.Tau2) {
+		return false
+	}
+	zs := make([]*mod.Int, len(is))
+	for k, i := range is {
+		if i < 0 || i >= vc.n {
+			return false
+		}
+		zs[k] = vc.domain[i]
+	}
+	return Polynomial_commitment.VerifyBatchProof(vc.ts, c, proof, zs, vs)
+}
+
+// points returns the domain points and the elements of v at the indexes is
+func (vc *VectorCommitment) points(v []*mod.Int, is []int) ([]*mod.Int, []*mod.Int, error) {
+	zs := make([]*mod.Int, len(is))
+	ys := make([]*mod.Int, len(is))
+	for k, i := range is {
+		if i < 0 || i >= vc.n {
+			return nil, nil, fmt.Errorf("index %d out of range [0, %d)", i, vc.n)
+		}
+		zs[k], ys[k] = vc.domain[i], v[i]
+	}
+	return zs, ys, nil
+}
+
+//
+// Updates
+//
+
+// UpdateCommitment returns the commitment after adding delta to the element
+// i: c + δ·[Lᵢ(t)]₁
+func (vc *VectorCommitment) UpdateCommitment(c primitives.G1, i int, delta *mod.Int) (primitives.G1, error) {
+	if i < 0 || i >= vc.n {
+		return nil, fmt.Errorf("index %d out of range [0, %d)", i, vc.n)
+	}
+	return c.Add(vc.lagrange[i].ScalarMult(&delta.V)), nil
+}
+
+// PrecomputeUpdateKeys computes the update keys needed by UpdateProof:
+// aᵢ = [A(t)/(t - ωⁱ)]₁ for A(x) = xⁿ - 1, with one NTT over 𝔾₁, and
+// uᵢ = [(Lᵢ(t) - 1)/(t - ωⁱ)]₁ with one multi-scalar multiplication of n
+// points each. The uᵢ cost O(n²) group operations in total, minutes for
+// n = 4096, so the keys are meant to be computed once and reused.
+func (vc *VectorCommitment) PrecomputeUpdateKeys() error {
+	// A(x)/(x - ωⁱ) = Σₖ ω^{i(n-1-k)} xᵏ, so aᵢ = Σⱼ ωⁱʲ [t^{n-1-j}]₁
+	reversed := make([]primitives.G1, vc.n)
+	for j := range reversed {
+		reversed[j] = vc.ts.Tau1[vc.n-1-j]
+	}
+	a, err := primitives.NTTG1(reversed)
+	if err != nil {
+		return err
+	}
+
+	// (Lᵢ(x) - 1)/(x - ωⁱ) in Lagrange form:
+	// -1/(ωʲ - ωⁱ) at ωʲ for j ≠ i, and L'ᵢ(ωⁱ) = (n-1)/(2ωⁱ) at ωⁱ
+	u := make([]primitives.G1, vc.n)
+	half := new(mod.Int).Div(vc.field.NewElement(int64(vc.n-1)), vc.field.NewElement(2))
+	for i := range u {
+		q := make([]*mod.Int, vc.n)
+		for j := range q {
+			if j == i {
+				q[j] = new(mod.Int).Div(half, vc.domain[i]).(*mod.Int)
+				continue
+			}
+			q[j] = new(mod.Int).Div(vc.field.NewElement(-1), new(mod.Int).Sub(vc.domain[j], vc.domain[i])).(*mod.Int)
+		}
+		u[i] = primitives.MultiScalarMultG1(vc.ts.Curve, vc.lagrange, primitives.Scalars(q))
+	}
+	vc.a, vc.u = a, u
+	return nil
+}
+
+// UpdateProof returns the proof of the element j after adding delta to the
+// element i, in O(1) group operations:
+//
+//	πⱼ + δ·uᵢ                              if i = j
+//	πⱼ + δ·(aᵢ - aⱼ)/(A'(ωⁱ)(ωⁱ - ωʲ))     otherwise, with A'(ωⁱ) = n·ω⁻ⁱ
+func (vc *VectorCommitment) UpdateProof(proof primitives.G1, j, i int, delta *mod.Int) (primitives.G1, error) {
+	if vc.a == nil {
+		return nil, fmt.Errorf("the update keys are not computed, see PrecomputeUpdateKeys")
+	}
+	if i < 0 || i >= vc.n || j < 0 || j >= vc.n {
+		return nil, fmt.Errorf("indexes %d and %d out of range [0, %d)", j, i, vc.n)
+	}
+	if i == j {
+		return proof.Add(vc.u[i].ScalarMult(&delta.V)), nil
+	}
+	// δ·ωⁱ / (n(ωⁱ - ωʲ))
+	k := new(mod.Int).Mul(delta, vc.domain[i])
+	den := new(mod.Int).Mul(vc.field.NewElement(int64(vc.n)), new(mod.Int).Sub(vc.domain[i], vc.domain[j]))
+	k = new(mod.Int).Div(k, den)
+	return proof.Add(vc.a[i].Add(vc.a[j].Neg()).ScalarMult(&k.(*mod.Int).V)), nil
+}
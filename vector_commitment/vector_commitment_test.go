@@ -0,0 +1,121 @@
+package vector_commitment
+
+import (
+	"commitment/Polynomial_commitment"
+	"commitment/primitives"
+	"github.com/drand/kyber/group/mod"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newTestVectorCommitment(t *testing.T, curve primitives.Curve, n int) *VectorCommitment {
+	ts, err := Polynomial_commitment.NewTrustedSetupOver(curve, n)
+	assert.Nil(t, err)
+	vc, err := NewVectorCommitment(ts, n)
+	assert.Nil(t, err)
+	return vc
+}
+
+func TestVectorCommitment_Open(t *testing.T) {
+	for _, curve := range []primitives.Curve{primitives.CurveBN254, primitives.CurveBLS12381} {
+		vc := newTestVectorCommitment(t, curve, 8)
+		v, err := vc.field.RandVector(vc.n)
+		assert.Nil(t, err)
+		c, err := vc.Commit(v)
+		assert.Nil(t, err)
+		for i := range v {
+			proof, err := vc.Open(v, i)
+			assert.Nil(t, err)
+			assert.True(t, vc.Verify(c, i, v[i], proof), curve.Name())
+			assert.False(t, vc.Verify(c, (i+1)%vc.n, v[i], proof), curve.Name())
+		}
+
+		_, err = vc.Commit(v[1:])
+		assert.NotNil(t, err)
+		_, err = vc.Open(v, vc.n)
+		assert.NotNil(t, err)
+	}
+}
+
+func TestVectorCommitment_OpenMulti(t *testing.T) {
+	vc := newTestVectorCommitment(t, primitives.CurveBN254, 16)
+	v, err := vc.field.RandVector(vc.n)
+	assert.Nil(t, err)
+	c, err := vc.Commit(v)
+	assert.Nil(t, err)
+
+	is := []int{1, 4, 5, 11}
+	vs := []*mod.Int{v[1], v[4], v[5], v[11]}
+	proof, err := vc.OpenMulti(v, is)
+	assert.Nil(t, err)
+	assert.True(t, vc.VerifyMulti(c, is, vs, proof))
+
+	vs[2] = v[6]
+	assert.False(t, vc.VerifyMulti(c, is, vs, proof))
+	assert.False(t, vc.VerifyMulti(c, is[1:], vs[1:], proof))
+
+	_, err = vc.OpenMulti(v, []int{1, 1})
+	assert.NotNil(t, err)
+}
+
+func TestVectorCommitment_Update(t *testing.T) {
+	vc := newTestVectorCommitment(t, primitives.CurveBN254, 8)
+	assert.Nil(t, vc.PrecomputeUpdateKeys())
+	v, err := vc.field.RandVector(vc.n)
+	assert.Nil(t, err)
+	c, err := vc.Commit(v)
+	assert.Nil(t, err)
+	proofs := make([]primitives.G1, vc.n)
+	for j := range proofs {
+		proofs[j], err = vc.Open(v, j)
+		assert.Nil(t, err)
+	}
+
+	i := 3
+	delta := vc.field.NewElement(42)
+	c, err = vc.UpdateCommitment(c, i, delta)
+	assert.Nil(t, err)
+	v[i] = new(mod.Int).Add(v[i], delta).(*mod.Int)
+	expected, _ := vc.Commit(v)
+	assert.True(t, c.Equal(expected))
+
+	for j := range proofs {
+		proof, err := vc.UpdateProof(proofs[j], j, i, delta)
+		assert.Nil(t, err)
+		fresh, _ := vc.Open(v, j)
+		assert.True(t, proof.Equal(fresh), j)
+		assert.True(t, vc.Verify(c, j, v[j], proof))
+	}
+}
+
+func TestVectorCommitment_UpdateWithoutKeys(t *testing.T) {
+	vc := newTestVectorCommitment(t, primitives.CurveBN254, 4)
+	v, err := vc.field.RandVector(vc.n)
+	assert.Nil(t, err)
+	proof, _ := vc.Open(v, 0)
+	_, err = vc.UpdateProof(proof, 0, 1, vc.field.NewElement(1))
+	assert.NotNil(t, err)
+}
+
+func TestVectorCommitment_OutOfRange(t *testing.T) {
+	vc := newTestVectorCommitment(t, primitives.CurveBN254, 4)
+	assert.Nil(t, vc.PrecomputeUpdateKeys())
+	v, err := vc.field.RandVector(vc.n)
+	assert.Nil(t, err)
+	c, _ := vc.Commit(v)
+	proof, _ := vc.Open(v, 0)
+	one := vc.field.NewElement(1)
+	for _, i := range []int{-1, 4} {
+		_, err := vc.Point(i)
+		assert.NotNil(t, err, i)
+		_, err = vc.UpdateCommitment(c, i, one)
+		assert.NotNil(t, err, i)
+		_, err = vc.UpdateProof(proof, 0, i, one)
+		assert.NotNil(t, err, i)
+		// ωⁱ⁺ⁿ = ωⁱ must not reach 1/(ωⁱ - ωʲ)
+		_, err = vc.UpdateProof(proof, i, 0, one)
+		assert.NotNil(t, err, i)
+	}
+	_, err = vc.UpdateProof(proof, 4, 0, one)
+	assert.NotNil(t, err)
+}
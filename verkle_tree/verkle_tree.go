@@ -0,0 +1,372 @@
+// Package verkle_tree implements a Verkle tree: a Merkle tree of width 256
+// whose internal nodes are KZG vector commitments to the hashes of their
+// children, so that a proof of many keys is a single aggregated opening of
+// the commitments along their paths instead of all the siblings.
+// https://math.mit.edu/research/highschool/primes/materials/2018/Kuszmaul.pdf
+package verkle_tree
+
+import (
+	"bytes"
+	"commitment/Polynomial_commitment"
+	"commitment/primitives"
+	"commitment/vector_commitment"
+	"crypto/sha256"
+	"github.com/drand/kyber/group/mod"
+	"math/big"
+)
+
+const (
+	// Width is the number of children of an internal node
+	Width = 256
+	// KeySize is the size of the keys, one byte of the key per level
+	KeySize = 32
+)
+
+// Key is a key of the tree
+type Key [KeySize]byte
+
+// Tree is a Verkle tree mapping keys to values. Leaves are placed at the
+// shallowest level where their key prefix is unique.
+type Tree struct {
+	ts   *Polynomial_commitment.TrustedSetup
+	vc   *vector_commitment.VectorCommitment
+	root *internalNode
+}
+
+type node interface{}
+
+type internalNode struct {
+	children   [Width]node
+	values     []*mod.Int // the field elements committed, 0 for empty children
+	commitment primitives.G1
+}
+
+type leafNode struct {
+	key   Key
+	value []byte
+}
+
+// NewTree returns an empty tree, the trusted setup needs at least Width points
+func NewTree(ts *Polynomial_commitment.TrustedSetup) (*Tree, error) {
+	vc, err := vector_commitment.NewVectorCommitment(ts, Width)
+	if err != nil {
+		return nil, err
+	}
+	t := &Tree{ts: ts, vc: vc}
+	t.root = t.newInternalNode()
+	return t, nil
+}
+
+func (t *Tree) newInternalNode() *internalNode {
+	n := &internalNode{values: make([]*mod.Int, Width), commitment: primitives.G1Zero(t.ts.Curve)}
+	for i := range n.values {
+		n.values[i] = t.ts.Curve.ScalarField().NewElement(0)
+	}
+	return n
+}
+
+// Root returns the commitment of the root node
+func (t *Tree) Root() primitives.G1 {
+	return t.root.commitment
+}
+
+// Get returns the value of the key, and whether it is in the tree
+func (t *Tree) Get(key Key) ([]byte, bool) {
+	n := t.root
+	for depth := 0; depth < KeySize; depth++ {
+		switch child := n.children[key[depth]].(type) {
+		case *internalNode:
+			n = child
+		case *leafNode:
+			if child.key != key {
+				return nil, false
+			}
+			return child.value, true
+		default:
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// Insert sets the value of the key, inserting or updating it. The
+// commitments along the path are updated in O(1) group operations each.
+func (t *Tree) Insert(key Key, value []byte) error {
+	return t.insert(t.root, 0, &leafNode{key, append([]byte{}, value...)})
+}
+
+func (t *Tree) insert(n *internalNode, depth int, leaf *leafNode) error {
+	i := int(leaf.key[depth])
+	switch child := n.children[i].(type) {
+	case *internalNode:
+		if err := t.insert(child, depth+1, leaf); err != nil {
+			return err
+		}
+		return t.set(n, i, child)
+	case *leafNode:
+		if child.key == leaf.key {
+			return t.set(n, i, leaf)
+		}
+		// both keys share the prefix up to depth, split
+		split := t.newInternalNode()
+		if err := t.insert(split, depth+1, child); err != nil {
+			return err
+		}
+		if err := t.insert(split, depth+1, leaf); err != nil {
+			return err
+		}
+		return t.set(n, i, split)
+	default:
+		return t.set(n, i, leaf)
+	}
+}
+
+// set sets the child i of n, updating the commitment of n with the change of
+// its value
+func (t *Tree) set(n *internalNode, i int, child node) error {
+	v := t.hash(child)
+	delta := new(mod.Int).Sub(v, n.values[i]).(*mod.Int)
+	c, err := t.vc.UpdateCommitment(n.commitment, i, delta)
+	if err != nil {
+		return err
+	}
+	n.children[i], n.values[i], n.commitment = child, v, c
+	return nil
+}
+
+// hash maps a child to the field element committed by its parent
+func (t *Tree) hash(n node) *mod.Int {
+	switch n := n.(type) {
+	case *internalNode:
+		return t.commitmentToField(n.commitment)
+	case *leafNode:
+		return t.leafToField(n.key, n.value)
+	}
+	return t.ts.Curve.ScalarField().NewElement(0)
+}
+
+func (t *Tree) commitmentToField(c primitives.G1) *mod.Int {
+	return t.hashToField([]byte{0}, c.Marshal())
+}
+
+func (t *Tree) leafToField(key Key, value []byte) *mod.Int {
+	return t.hashToField([]byte{1}, key[:], value)
+}
+
+func (t *Tree) hashToField(bs ...[]byte) *mod.Int {
+	h := sha256.New()
+	for _, b := range bs {
+		h.Write(b)
+	}
+	return t.ts.Curve.ScalarField().NewElementFromBig(new(big.Int).SetBytes(h.Sum(nil)))
+}
+
+//
+// Proofs
+//
+
+// Proof proves the values, or the absence, of a list of keys. The internal
+// nodes along the paths of the keys are opened at the indexes of the keys
+// with a single SHPLONK proof.
+type Proof struct {
+	// Commitments of the internal nodes on the paths, except the root, in
+	// the order they are first visited by the keys
+	Commitments []primitives.G1
+	// Depths is, for each key, the number of internal nodes on its path
+	Depths []int
+	// Leaves is, for each key, the leaf found at the end of its path, nil
+	// for an empty child. A leaf with another key proves the absence.
+	Leaves  []*Leaf
+	Opening *Polynomial_commitment.ShplonkProof
+}
+
+// Leaf is a leaf of the tree
+type Leaf struct {
+	Key   Key
+	Value []byte
+}
+
+// opening collects the indexes opened in one internal node
+type opening struct {
+	commitment primitives.G1
+	node       *internalNode
+	indexes    []int
+	values     map[int]*mod.Int
+}
+
+// Prove returns the proof of the keys
+func (t *Tree) Prove(keys []Key) (*Proof, error) {
+	proof := &Proof{Depths: make([]int, len(keys)), Leaves: make([]*Leaf, len(keys))}
+	openings := []*opening{}
+	byPrefix := map[string]*opening{}
+	for k, key := range keys {
+		n := t.root
+		for depth := 0; ; depth++ {
+			prefix := string(key[:depth])
+			o, ok := byPrefix[prefix]
+			if !ok {
+				o = &opening{commitment: n.commitment, node: n, values: map[int]*mod.Int{}}
+				byPrefix[prefix] = o
+				openings = append(openings, o)
+				if depth > 0 {
+					proof.Commitments = append(proof.Commitments, n.commitment)
+				}
+			}
+			i := int(key[depth])
+			if _, ok := o.values[i]; !ok {
+				o.indexes = append(o.indexes, i)
+				o.values[i] = n.values[i]
+			}
+			child, ok := n.children[i].(*internalNode)
+			if !ok {
+				proof.Depths[k] = depth + 1
+				if leaf, ok := n.children[i].(*leafNode); ok {
+					proof.Leaves[k] = &Leaf{leaf.key, leaf.value}
+				}
+				break
+			}
+			n = child
+		}
+	}
+
+	ps := make([]*primitives.Polynomial, len(openings))
+	cs := make([]primitives.G1, len(openings))
+	points := make([][]*mod.Int, len(openings))
+	for j, o := range openings {
+		coefficients, err := primitives.InverseNTT(o.node.values)
+		if err != nil {
+			return nil, err
+		}
+		zs, err := t.points(o.indexes)
+		if err != nil {
+			return nil, err
+		}
+		ps[j], cs[j], points[j] = new(primitives.Polynomial).Init(coefficients), o.commitment, zs
+	}
+	shplonk, _, err := Polynomial_commitment.ShplonkOpen(t.ts, ps, cs, points, t.transcript(keys))
+	if err != nil {
+		return nil, err
+	}
+	proof.Opening = shplonk
+	return proof, nil
+}
+
+// VerifyProof verifies the proof of the keys against the root commitment.
+// values[i] is the value of keys[i], nil when the key is absent.
+func (t *Tree) VerifyProof(root primitives.G1, keys []Key, values [][]byte, proof *Proof) bool {
+	if len(keys) != len(values) || len(keys) != len(proof.Depths) || len(keys) != len(proof.Leaves) || proof.Opening == nil {
+		return false
+	}
+	openings := []*opening{}
+	byPrefix := map[string]*opening{}
+	next := 0
+	for k, key := range keys {
+		depth := proof.Depths[k]
+		if depth < 1 || depth > KeySize {
+			return false
+		}
+		// the commitments of the internal nodes on the path
+		path := make([]*opening, depth)
+		for d := 0; d < depth; d++ {
+			prefix := string(key[:d])
+			o, ok := byPrefix[prefix]
+			if !ok {
+				c := root
+				if d > 0 {
+					if next >= len(proof.Commitments) {
+						return false
+					}
+					c, next = proof.Commitments[next], next+1
+				}
+				o = &opening{commitment: c, values: map[int]*mod.Int{}}
+				byPrefix[prefix] = o
+				openings = append(openings, o)
+			}
+			path[d] = o
+		}
+		// the values opened along the path
+		for d := 0; d < depth; d++ {
+			var v *mod.Int
+			if d < depth-1 {
+				v = t.commitmentToField(path[d+1].commitment)
+			} else {
+				v = t.verifyLeaf(key, values[k], depth, proof.Leaves[k])
+				if v == nil {
+					return false
+				}
+			}
+			i := int(key[d])
+			if prev, ok := path[d].values[i]; ok {
+				if !prev.Equal(v) {
+					return false
+				}
+				continue
+			}
+			path[d].indexes = append(path[d].indexes, i)
+			path[d].values[i] = v
+		}
+	}
+	if next != len(proof.Commitments) {
+		return false
+	}
+
+	cs := make([]primitives.G1, len(openings))
+	points := make([][]*mod.Int, len(openings))
+	evals := make([][]*mod.Int, len(openings))
+	for j, o := range openings {
+		zs, err := t.points(o.indexes)
+		if err != nil {
+			return false
+		}
+		cs[j], points[j] = o.commitment, zs
+		evals[j] = make([]*mod.Int, len(o.indexes))
+		for l, i := range o.indexes {
+			evals[j][l] = o.values[i]
+		}
+	}
+	return Polynomial_commitment.ShplonkVerify(t.ts, cs, points, evals, proof.Opening, t.transcript(keys))
+}
+
+// verifyLeaf checks the end of the path of a key and returns the value
+// committed for it, nil if the leaf does not match the claimed value
+func (t *Tree) verifyLeaf(key Key, value []byte, depth int, leaf *Leaf) *mod.Int {
+	if leaf == nil {
+		// empty child, the key is absent
+		if value != nil {
+			return nil
+		}
+		return t.ts.Curve.ScalarField().NewElement(0)
+	}
+	if !bytes.Equal(leaf.Key[:depth], key[:depth]) {
+		return nil
+	}
+	if leaf.Key == key {
+		if value == nil || !bytes.Equal(leaf.Value, value) {
+			return nil
+		}
+	} else if value != nil {
+		// another key at the end of the path, the key is absent
+		return nil
+	}
+	return t.leafToField(leaf.Key, leaf.Value)
+}
+
+func (t *Tree) points(indexes []int) ([]*mod.Int, error) {
+	zs := make([]*mod.Int, len(indexes))
+	for l, i := range indexes {
+		z, err := t.vc.Point(i)
+		if err != nil {
+			return nil, err
+		}
+		zs[l] = z
+	}
+	return zs, nil
+}
+
+func (t *Tree) transcript(keys []Key) *primitives.Transcript {
+	tr := primitives.NewTranscript("verkle_tree")
+	for _, key := range keys {
+		tr.AppendBytes("key", key[:])
+	}
+	return tr
+}
@@ -0,0 +1,96 @@
+package verkle_tree
+
+import (
+	"commitment/Polynomial_commitment"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newTestTree(t *testing.T) *Tree {
+	ts, err := Polynomial_commitment.NewTrustedSetup(Width)
+	assert.Nil(t, err)
+	tree, err := NewTree(ts)
+	assert.Nil(t, err)
+	return tree
+}
+
+func key(bs ...byte) Key {
+	var k Key
+	copy(k[:], bs)
+	return k
+}
+
+func TestTree_InsertGet(t *testing.T) {
+	tree := newTestTree(t)
+	empty := tree.Root()
+
+	assert.Nil(t, tree.Insert(key(1, 2, 3), []byte("a")))
+	assert.Nil(t, tree.Insert(key(1, 2, 4), []byte("b"))) // shares a prefix of 2 bytes
+	assert.Nil(t, tree.Insert(key(7), []byte("c")))
+	root := tree.Root()
+	assert.False(t, root.Equal(empty))
+
+	v, ok := tree.Get(key(1, 2, 3))
+	assert.True(t, ok)
+	assert.Equal(t, []byte("a"), v)
+	v, ok = tree.Get(key(1, 2, 4))
+	assert.True(t, ok)
+	assert.Equal(t, []byte("b"), v)
+	_, ok = tree.Get(key(1, 2, 5))
+	assert.False(t, ok)
+	_, ok = tree.Get(key(2))
+	assert.False(t, ok)
+
+	// updates change the root, and the root only depends on the content
+	assert.Nil(t, tree.Insert(key(1, 2, 3), []byte("d")))
+	assert.False(t, tree.Root().Equal(root))
+	assert.Nil(t, tree.Insert(key(1, 2, 3), []byte("a")))
+	assert.True(t, tree.Root().Equal(root))
+
+	// same setup, other insertion order
+	other := &Tree{ts: tree.ts, vc: tree.vc}
+	other.root = other.newInternalNode()
+	assert.Nil(t, other.Insert(key(7), []byte("c")))
+	assert.Nil(t, other.Insert(key(1, 2, 4), []byte("b")))
+	assert.Nil(t, other.Insert(key(1, 2, 3), []byte("a")))
+	assert.True(t, other.Root().Equal(root))
+}
+
+func TestTree_Prove(t *testing.T) {
+	tree := newTestTree(t)
+	assert.Nil(t, tree.Insert(key(1, 2, 3), []byte("a")))
+	assert.Nil(t, tree.Insert(key(1, 2, 4), []byte("b")))
+	assert.Nil(t, tree.Insert(key(1, 5), []byte("c")))
+	assert.Nil(t, tree.Insert(key(9), []byte("d")))
+	root := tree.Root()
+
+	keys := []Key{
+		key(1, 2, 3),
+		key(1, 2, 4),
+		key(9),
+		key(1, 2, 6), // absent, empty child
+		key(9, 1),    // absent, another leaf
+	}
+	values := [][]byte{[]byte("a"), []byte("b"), []byte("d"), nil, nil}
+	proof, err := tree.Prove(keys)
+	assert.Nil(t, err)
+	// the root, [1] and [1, 2]
+	assert.Equal(t, 2, len(proof.Commitments))
+	assert.True(t, tree.VerifyProof(root, keys, values, proof))
+
+	// wrong values
+	values[1] = []byte("x")
+	assert.False(t, tree.VerifyProof(root, keys, values, proof))
+	values[1] = []byte("b")
+	values[4] = []byte("d")
+	assert.False(t, tree.VerifyProof(root, keys, values, proof))
+	values[4] = nil
+
+	// wrong root
+	assert.Nil(t, tree.Insert(key(1, 5), []byte("e")))
+	assert.False(t, tree.VerifyProof(tree.Root(), keys, values, proof))
+
+	// tampered leaf
+	proof.Leaves[2].Value = []byte("x")
+	assert.False(t, tree.VerifyProof(root, keys, [][]byte{[]byte("a"), []byte("b"), []byte("x"), nil, nil}, proof))
+}
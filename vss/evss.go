@@ -0,0 +1,57 @@
+package vss
+
+import (
+	"commitment/Polynomial_commitment"
+	"commitment/primitives"
+	"fmt"
+	"github.com/drand/kyber/group/mod"
+)
+
+// EVSSCommitment is the KZG commitment to the polynomial of the dealer with
+// the proof that its degree is at most t, of constant size whatever the
+// threshold. Each share comes with its evaluation proof.
+// https://cacr.uwaterloo.ca/techreports/2010/cacr2010-10.pdf
+type EVSSCommitment struct {
+	C     primitives.G1
+	Bound *Polynomial_commitment.DegreeBoundProof
+}
+
+// EVSSDeal splits the secret, of the scalar field of the setup, into n
+// shares with threshold t, and returns them with their evaluation proofs
+// and the commitment to broadcast
+func EVSSDeal(ts *Polynomial_commitment.TrustedSetup, secret *mod.Int, t, n int) ([]*Share, []primitives.G1, *EVSSCommitment, error) {
+	if err := checkThreshold(t, n); err != nil {
+		return nil, nil, nil, err
+	}
+	f, err := randomPolynomial(secret, t)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	c, bound, err := Polynomial_commitment.CommitWithDegreeBound(ts, f, t)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	shares := split(f, n)
+	proofs := make([]primitives.G1, n)
+	field := ts.Curve.ScalarField()
+	for i, s := range shares {
+		proofs[i], err = Polynomial_commitment.EvaluationProof(ts, f, field.NewElement(int64(s.Index)), s.Value)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("share %d: %v", s.Index, err)
+		}
+	}
+	return shares, proofs, &EVSSCommitment{c, bound}, nil
+}
+
+// EVSSVerify checks the share against the commitment of a polynomial of
+// degree at most t with its evaluation proof
+func EVSSVerify(ts *Polynomial_commitment.TrustedSetup, c *EVSSCommitment, t int, share *Share, proof primitives.G1) bool {
+	if c == nil || c.C == nil || c.Bound == nil || c.Bound.Shifted == nil || share == nil || share.Value == nil || proof == nil {
+		return false
+	}
+	if share.Index <= 0 || c.Bound.D != t {
+		return false
+	}
+	z := ts.Curve.ScalarField().NewElement(int64(share.Index))
+	return Polynomial_commitment.VerifyWithDegreeBound(ts, c.C, proof, c.Bound, z, share.Value)
+}
@@ -0,0 +1,38 @@
+package vss
+
+import (
+	"commitment/Polynomial_commitment"
+	"commitment/primitives"
+	"github.com/drand/kyber/group/mod"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEVSS(t *testing.T) {
+	for _, curve := range []primitives.Curve{primitives.CurveBN254, primitives.CurveBLS12381} {
+		ts, err := Polynomial_commitment.NewTrustedSetupOver(curve, 8)
+		assert.Nil(t, err)
+		secret, err := curve.ScalarField().Rand()
+		assert.Nil(t, err)
+		shares, proofs, c, err := EVSSDeal(ts, secret, 3, 6)
+		assert.Nil(t, err)
+
+		for i := range shares {
+			assert.True(t, EVSSVerify(ts, c, 3, shares[i], proofs[i]), curve.Name())
+		}
+		assert.False(t, EVSSVerify(ts, c, 3, shares[0], proofs[1]), curve.Name())
+		wrong := &Share{Index: 1, Value: new(mod.Int).Add(shares[0].Value, curve.ScalarField().NewElement(1)).(*mod.Int)}
+		assert.False(t, EVSSVerify(ts, c, 3, wrong, proofs[0]), curve.Name())
+		// the degree bound is the threshold
+		assert.False(t, EVSSVerify(ts, c, 4, shares[0], proofs[0]), curve.Name())
+		// malformed commitments and shares are rejected
+		assert.False(t, EVSSVerify(ts, &EVSSCommitment{C: c.C}, 3, shares[0], proofs[0]), curve.Name())
+		assert.False(t, EVSSVerify(ts, nil, 3, shares[0], proofs[0]), curve.Name())
+		assert.False(t, EVSSVerify(ts, c, 3, &Share{Index: 1}, proofs[0]), curve.Name())
+		assert.False(t, EVSSVerify(ts, c, 3, shares[0], nil), curve.Name())
+
+		s, err := Reconstruct(shares[1:5], 3)
+		assert.Nil(t, err)
+		assert.True(t, s.Equal(secret))
+	}
+}
@@ -0,0 +1,101 @@
+package vss
+
+import (
+	"bytes"
+	"commitment/primitives"
+	"fmt"
+	"github.com/drand/kyber/group/mod"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"math/big"
+)
+
+// FeldmanCommitment holds the commitments Cₖ = [aₖ]G to the coefficients of
+// the polynomial of the dealer. C₀ = [s]G reveals the public key of the
+// secret, so the secret is only computationally hidden.
+// https://ieeexplore.ieee.org/document/4568297
+type FeldmanCommitment struct {
+	C []*bn256.G1
+}
+
+// FeldmanDeal splits the secret, of the BN254 scalar field, into n shares
+// with threshold t, and returns the shares with the commitment to broadcast
+func FeldmanDeal(secret *mod.Int, t, n int) ([]*Share, *FeldmanCommitment, error) {
+	if err := checkThreshold(t, n); err != nil {
+		return nil, nil, err
+	}
+	if err := checkBN254(secret); err != nil {
+		return nil, nil, err
+	}
+	f, err := randomPolynomial(secret, t)
+	if err != nil {
+		return nil, nil, err
+	}
+	return split(f, n), feldmanCommit(f), nil
+}
+
+// checkBN254 checks that the secret is in the BN254 scalar field, the one of
+// the commitments in bn256 G1
+func checkBN254(secret *mod.Int) error {
+	if secret == nil || secret.M == nil || secret.M.Cmp(primitives.BN254.Modulus()) != 0 {
+		return fmt.Errorf("the secret must be in the BN254 scalar field")
+	}
+	return nil
+}
+
+func feldmanCommit(f *primitives.Polynomial) *FeldmanCommitment {
+	c := &FeldmanCommitment{make([]*bn256.G1, f.Degree)}
+	for k := range c.C {
+		c.C[k] = new(bn256.G1).ScalarBaseMult(&f.Coefficient[k].V)
+	}
+	return c
+}
+
+// Threshold returns the degree t of the committed polynomial
+func (c *FeldmanCommitment) Threshold() int {
+	return len(c.C) - 1
+}
+
+// PublicKey returns [s]G
+func (c *FeldmanCommitment) PublicKey() *bn256.G1 {
+	return c.C[0]
+}
+
+// Eval returns [f(i)]G = Σ iᵏ·Cₖ, computed from the commitment alone
+func (c *FeldmanCommitment) Eval(i int) *bn256.G1 {
+	return evalCommitments(c.C, i)
+}
+
+// FeldmanVerify checks the share against the commitment: [f(i)]G = Σ iᵏ·Cₖ
+func FeldmanVerify(c *FeldmanCommitment, share *Share) bool {
+	if c == nil || !validCommitments(c.C) || !validShare(share) || share.Index <= 0 {
+		return false
+	}
+	g := new(bn256.G1).ScalarBaseMult(&share.Value.V)
+	return bytes.Equal(g.Marshal(), c.Eval(share.Index).Marshal())
+}
+
+// validCommitments reports whether cs is non empty without nil points
+func validCommitments(cs []*bn256.G1) bool {
+	for _, c := range cs {
+		if c == nil {
+			return false
+		}
+	}
+	return len(cs) > 0
+}
+
+// validShare reports whether the share has a value
+func validShare(s *Share) bool {
+	return s != nil && s.Value != nil
+}
+
+// evalCommitments returns Σ iᵏ·Cₖ with Horner's rule
+func evalCommitments(cs []*bn256.G1, i int) *bn256.G1 {
+	x := big.NewInt(int64(i))
+	r := new(bn256.G1).ScalarBaseMult(new(big.Int))
+	for k := len(cs) - 1; k >= 0; k-- {
+		r.ScalarMult(r, x)
+		r.Add(r, cs[k])
+	}
+	return r
+}
@@ -0,0 +1,39 @@
+package vss
+
+import (
+	"commitment/primitives"
+	"github.com/drand/kyber/group/mod"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFeldman(t *testing.T) {
+	secret, err := primitives.BN254.Rand()
+	assert.Nil(t, err)
+	shares, c, err := FeldmanDeal(secret, 3, 7)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, c.Threshold())
+	assert.Equal(t, new(bn256.G1).ScalarBaseMult(&secret.V).Marshal(), c.PublicKey().Marshal())
+
+	for _, s := range shares {
+		assert.True(t, FeldmanVerify(c, s))
+	}
+	wrong := &Share{Index: 2, Value: new(mod.Int).Add(shares[1].Value, primitives.BN254.NewElement(1)).(*mod.Int)}
+	assert.False(t, FeldmanVerify(c, wrong))
+	assert.False(t, FeldmanVerify(c, &Share{Index: 3, Value: shares[1].Value}))
+	assert.False(t, FeldmanVerify(c, nil))
+	assert.False(t, FeldmanVerify(c, &Share{Index: 1}))
+	assert.False(t, FeldmanVerify(nil, shares[0]))
+	assert.False(t, FeldmanVerify(&FeldmanCommitment{[]*bn256.G1{nil}}, shares[0]))
+
+	s, err := Reconstruct(shares[3:], 3)
+	assert.Nil(t, err)
+	assert.True(t, s.Equal(secret))
+
+	_, _, err = FeldmanDeal(secret, 7, 7)
+	assert.NotNil(t, err)
+	// the commitments are in bn256 G1, the secret must be a BN254 scalar
+	_, _, err = FeldmanDeal(primitives.BLS12381.NewElement(1), 3, 7)
+	assert.NotNil(t, err)
+}
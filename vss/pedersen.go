@@ -0,0 +1,109 @@
+package vss
+
+import (
+	"bytes"
+	"commitment/primitives"
+	"github.com/drand/kyber/group/mod"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+// PedersenCommitment holds the commitments Cₖ = [aₖ]G + [bₖ]H to the
+// coefficients of the polynomial f of the dealer and of a random blinding
+// polynomial f'. The secret is information theoretically hidden.
+// (Pedersen, Non-interactive and information-theoretic secure VSS, 1991)
+type PedersenCommitment struct {
+	C []*bn256.G1
+}
+
+// PedersenH returns the second base H, hashed to the curve so that nobody
+// knows its discrete logarithm to G
+func PedersenH() *bn256.G1 {
+	return primitives.HashToBN254G1("vss H", nil).P
+}
+
+// Dealer holds the polynomial f of a dealer and its blinding polynomial f',
+// to hand out shares and commitments
+type Dealer struct {
+	f, blind *primitives.Polynomial
+	n        int
+}
+
+// NewDealer returns a dealer of the secret, of the BN254 scalar field, to n
+// participants with threshold t
+func NewDealer(secret *mod.Int, t, n int) (*Dealer, error) {
+	if err := checkThreshold(t, n); err != nil {
+		return nil, err
+	}
+	if err := checkBN254(secret); err != nil {
+		return nil, err
+	}
+	f, err := randomPolynomial(secret, t)
+	if err != nil {
+		return nil, err
+	}
+	r, err := primitives.BN254.Rand()
+	if err != nil {
+		return nil, err
+	}
+	blind, err := randomPolynomial(r, t)
+	if err != nil {
+		return nil, err
+	}
+	return &Dealer{f, blind, n}, nil
+}
+
+// Shares returns the shares f(1), ..., f(n)
+func (d *Dealer) Shares() []*Share {
+	return split(d.f, d.n)
+}
+
+// BlindShares returns the blinding shares f'(1), ..., f'(n)
+func (d *Dealer) BlindShares() []*Share {
+	return split(d.blind, d.n)
+}
+
+// PedersenCommitment returns the commitments [aₖ]G + [bₖ]H
+func (d *Dealer) PedersenCommitment() *PedersenCommitment {
+	h := PedersenH()
+	c := &PedersenCommitment{make([]*bn256.G1, d.f.Degree)}
+	for k := range c.C {
+		c.C[k] = new(bn256.G1).ScalarBaseMult(&d.f.Coefficient[k].V)
+		c.C[k].Add(c.C[k], new(bn256.G1).ScalarMult(h, &d.blind.Coefficient[k].V))
+	}
+	return c
+}
+
+// FeldmanCommitment returns the commitments [aₖ]G, which reveal [s]G
+func (d *Dealer) FeldmanCommitment() *FeldmanCommitment {
+	return feldmanCommit(d.f)
+}
+
+// PedersenDeal splits the secret, of the BN254 scalar field, into n shares
+// f(i) with threshold t, and returns them with the blinding shares f'(i)
+// and the commitment to broadcast
+func PedersenDeal(secret *mod.Int, t, n int) ([]*Share, []*Share, *PedersenCommitment, error) {
+	d, err := NewDealer(secret, t, n)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return d.Shares(), d.BlindShares(), d.PedersenCommitment(), nil
+}
+
+// Threshold returns the degree t of the committed polynomials
+func (c *PedersenCommitment) Threshold() int {
+	return len(c.C) - 1
+}
+
+// PedersenVerify checks the share and the blinding share against the
+// commitment: [f(i)]G + [f'(i)]H = Σ iᵏ·Cₖ
+func PedersenVerify(c *PedersenCommitment, share, blind *Share) bool {
+	if c == nil || !validCommitments(c.C) || !validShare(share) || !validShare(blind) {
+		return false
+	}
+	if share.Index <= 0 || share.Index != blind.Index {
+		return false
+	}
+	g := new(bn256.G1).ScalarBaseMult(&share.Value.V)
+	g.Add(g, new(bn256.G1).ScalarMult(PedersenH(), &blind.Value.V))
+	return bytes.Equal(g.Marshal(), evalCommitments(c.C, share.Index).Marshal())
+}
@@ -0,0 +1,51 @@
+package vss
+
+import (
+	"commitment/primitives"
+	"github.com/drand/kyber/group/mod"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPedersen(t *testing.T) {
+	secret, err := primitives.BN254.Rand()
+	assert.Nil(t, err)
+	shares, blinds, c, err := PedersenDeal(secret, 2, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, c.Threshold())
+
+	for i := range shares {
+		assert.True(t, PedersenVerify(c, shares[i], blinds[i]))
+	}
+	assert.False(t, PedersenVerify(c, shares[0], blinds[1]))
+	wrong := &Share{Index: 1, Value: new(mod.Int).Add(shares[0].Value, primitives.BN254.NewElement(1)).(*mod.Int)}
+	assert.False(t, PedersenVerify(c, wrong, blinds[0]))
+	// malformed inputs are rejected
+	assert.False(t, PedersenVerify(c, shares[0], nil))
+	assert.False(t, PedersenVerify(c, nil, blinds[0]))
+	assert.False(t, PedersenVerify(c, &Share{Index: 1}, blinds[0]))
+	assert.False(t, PedersenVerify(nil, shares[0], blinds[0]))
+	assert.False(t, PedersenVerify(&PedersenCommitment{[]*bn256.G1{c.C[0], nil, c.C[2]}}, shares[0], blinds[0]))
+
+	s, err := Reconstruct(shares[2:], 2)
+	assert.Nil(t, err)
+	assert.True(t, s.Equal(secret))
+}
+
+func TestDealer(t *testing.T) {
+	secret, err := primitives.BN254.Rand()
+	assert.Nil(t, err)
+	d, err := NewDealer(secret, 1, 3)
+	assert.Nil(t, err)
+	shares, blinds := d.Shares(), d.BlindShares()
+	// both commitments are of the same polynomial
+	for i := range shares {
+		assert.True(t, PedersenVerify(d.PedersenCommitment(), shares[i], blinds[i]))
+		assert.True(t, FeldmanVerify(d.FeldmanCommitment(), shares[i]))
+	}
+	_, err = NewDealer(secret, -1, 3)
+	assert.NotNil(t, err)
+	_, err = NewDealer(primitives.BLS12381.NewElement(1), 1, 3)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,107 @@
+// Package vss implements verifiable secret sharing: a dealer splits a
+// secret s into n shares f(1), ..., f(n) of a random polynomial f of degree
+// t with f(0) = s (Shamir), over the field of s, and publishes commitments
+// to f against which each participant verifies their share.
+// Any t+1 shares reconstruct the secret, t shares reveal nothing about it.
+package vss
+
+import (
+	"commitment/primitives"
+	"fmt"
+	"github.com/drand/kyber/group/mod"
+)
+
+// Share is the evaluation f(Index) of the polynomial of the dealer,
+// Index being in 1..n
+type Share struct {
+	Index int
+	Value *mod.Int
+}
+
+// randomPolynomial returns a random polynomial f of degree t with f(0) = s,
+// over the field of s
+func randomPolynomial(s *mod.Int, t int) (*primitives.Polynomial, error) {
+	field := primitives.FieldOf(s.M)
+	coeffs := make([]*mod.Int, t+1)
+	coeffs[0] = new(mod.Int).Set(s).(*mod.Int)
+	for i := 1; i <= t; i++ {
+		c, err := field.Rand()
+		if err != nil {
+			return nil, err
+		}
+		coeffs[i] = c
+	}
+	return new(primitives.Polynomial).Init(coeffs), nil
+}
+
+// split returns the shares f(1), ..., f(n)
+func split(f *primitives.Polynomial, n int) []*Share {
+	field := f.Field()
+	shares := make([]*Share, n)
+	for i := range shares {
+		shares[i] = &Share{Index: i + 1, Value: f.Eval(field.NewElement(int64(i + 1)))}
+	}
+	return shares
+}
+
+func checkThreshold(t, n int) error {
+	if t < 0 || n <= t {
+		return fmt.Errorf("the threshold must be in 0..n-1, got t=%d and n=%d", t, n)
+	}
+	return nil
+}
+
+// LagrangeCoefficients returns the λᵢ such that f(0) = Σ λᵢ·f(indexes[i])
+// for any polynomial f of degree lower than len(indexes):
+// λᵢ = Π_{j≠i} xⱼ/(xⱼ - xᵢ)
+func LagrangeCoefficients(indexes []int) ([]*mod.Int, error) {
+	return LagrangeCoefficientsOver(primitives.BN254, indexes)
+}
+
+// LagrangeCoefficientsOver returns the Lagrange coefficients at 0 of the
+// indexes, for polynomials over the given field
+func LagrangeCoefficientsOver(field primitives.Field, indexes []int) ([]*mod.Int, error) {
+	seen := make(map[int]bool)
+	for _, x := range indexes {
+		if x <= 0 || seen[x] {
+			return nil, fmt.Errorf("the indexes must be positive and distinct, got %v", indexes)
+		}
+		seen[x] = true
+	}
+	ls := make([]*mod.Int, len(indexes))
+	for i, xi := range indexes {
+		num, den := field.NewElement(1), field.NewElement(1)
+		for _, xj := range indexes {
+			if xj == xi {
+				continue
+			}
+			num = new(mod.Int).Mul(num, field.NewElement(int64(xj))).(*mod.Int)
+			den = new(mod.Int).Mul(den, field.NewElement(int64(xj-xi))).(*mod.Int)
+		}
+		ls[i] = new(mod.Int).Div(num, den).(*mod.Int)
+	}
+	return ls, nil
+}
+
+// Reconstruct returns the secret f(0) from t+1 shares of a polynomial of
+// degree t, only the first t+1 shares are used
+func Reconstruct(shares []*Share, t int) (*mod.Int, error) {
+	if len(shares) < t+1 {
+		return nil, fmt.Errorf("need %d shares, got %d", t+1, len(shares))
+	}
+	shares = shares[:t+1]
+	indexes := make([]int, len(shares))
+	for i, s := range shares {
+		indexes[i] = s.Index
+	}
+	field := primitives.FieldOf(shares[0].Value.M)
+	ls, err := LagrangeCoefficientsOver(field, indexes)
+	if err != nil {
+		return nil, err
+	}
+	s := field.NewElement(0)
+	for i := range shares {
+		s = new(mod.Int).Add(s, new(mod.Int).Mul(ls[i], shares[i].Value)).(*mod.Int)
+	}
+	return s, nil
+}
@@ -0,0 +1,47 @@
+package vss
+
+import (
+	"commitment/primitives"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+func TestReconstruct(t *testing.T) {
+	secret, err := primitives.BN254.Rand()
+	assert.Nil(t, err)
+	f, err := randomPolynomial(secret, 2)
+	assert.Nil(t, err)
+	shares := split(f, 5)
+
+	s, err := Reconstruct([]*Share{shares[4], shares[1], shares[2]}, 2)
+	assert.Nil(t, err)
+	assert.True(t, s.Equal(secret))
+	s, err = Reconstruct(shares, 2)
+	assert.Nil(t, err)
+	assert.True(t, s.Equal(secret))
+
+	// t shares are not enough
+	_, err = Reconstruct(shares[:2], 2)
+	assert.NotNil(t, err)
+	_, err = Reconstruct([]*Share{shares[0], shares[0], shares[1]}, 2)
+	assert.NotNil(t, err)
+}
+
+func TestLagrangeCoefficients(t *testing.T) {
+	// f(x) = 7 + 3x: f(1) = 10, f(2) = 13
+	ls, err := LagrangeCoefficients([]int{1, 2})
+	assert.Nil(t, err)
+	assert.True(t, ls[0].Equal(primitives.BN254.NewElement(2)))
+	assert.True(t, ls[1].Equal(primitives.BN254.NewElement(-1)))
+
+	_, err = LagrangeCoefficients([]int{0, 1})
+	assert.NotNil(t, err)
+
+	// over F₁₇: λ₁ = 2, λ₂ = -1 = 16
+	f := primitives.NewPrimeField(big.NewInt(17), big.NewInt(3))
+	ls, err = LagrangeCoefficientsOver(f, []int{1, 2})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), ls[0].V.Int64())
+	assert.Equal(t, int64(16), ls[1].V.Int64())
+}
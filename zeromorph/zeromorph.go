@@ -0,0 +1,217 @@
+// Package zeromorph commits to multilinear polynomials with the univariate
+// KZG of Polynomial_commitment, by the Zeromorph reduction: f is mapped to
+// U(f)(X) = Σ_b f(b)·Xᵇ, and the multilinear identity
+//
+//	f(X) - f(u) = Σₖ (Xₖ - uₖ)·qₖ(X₀, ..., Xₖ₋₁)
+//
+// becomes the univariate identity
+//
+//	U(f) - v·Φₙ(X) = Σₖ (X^(2ᵏ)·Φₙ₋ₖ₋₁(X^(2ᵏ⁺¹)) - uₖ·Φₙ₋ₖ(X^(2ᵏ)))·U(qₖ)
+//
+// with Φₖ(X) = Σ_{i<2ᵏ} Xⁱ, checked at a random point with a single KZG
+// opening. https://eprint.iacr.org/2023/917
+package zeromorph
+
+import (
+	"commitment/Polynomial_commitment"
+	"commitment/primitives"
+	"fmt"
+	"github.com/drand/kyber/group/mod"
+	"math/big"
+)
+
+// Proof is the proof of an evaluation of a multilinear polynomial
+type Proof struct {
+	Q      []primitives.G1 // [U(qₖ)(t)]₁
+	QHat   primitives.G1   // [q̂(t)]₁, the batched degree check
+	Degree *Polynomial_commitment.DegreeBoundProof
+	Pi     primitives.G1 // the KZG opening of ζ + z·Z at x
+}
+
+// Commit generates the commitment [U(f)(t)]₁ to the multilinear polynomial f
+func Commit(ts *Polynomial_commitment.TrustedSetup, f *primitives.MultilinearPoly) (primitives.G1, error) {
+	if len(f.Evaluations) > len(ts.Tau1) {
+		return nil, fmt.Errorf("the trusted setup has %d points, %d are needed", len(ts.Tau1), len(f.Evaluations))
+	}
+	return Polynomial_commitment.Commit(ts, new(primitives.Polynomial).Init(f.Evaluations)), nil
+}
+
+// EvaluationProof generates the proof of f(u) = v
+func EvaluationProof(ts *Polynomial_commitment.TrustedSetup, f *primitives.MultilinearPoly, u []*mod.Int, v *mod.Int, tr *primitives.Transcript) (*Proof, error) {
+	n := f.NumVars
+	if len(u) != n {
+		return nil, fmt.Errorf("the polynomial has %d variables, got a point of %d", n, len(u))
+	}
+	c, err := Commit(ts, f)
+	if err != nil {
+		return nil, err
+	}
+	field := ts.Curve.ScalarField()
+
+	// qₖ(X₀, ..., Xₖ₋₁) = fₖ₊₁(..., 1) - fₖ₊₁(..., 0), fixing the last
+	// variable first: fₖ = fₖ₊₁(..., uₖ)
+	qs := make([][]*mod.Int, n)
+	fk := f
+	for k := n - 1; k >= 0; k-- {
+		half := len(fk.Evaluations) / 2
+		qs[k] = make([]*mod.Int, half)
+		for i := range qs[k] {
+			qs[k][i] = new(mod.Int).Sub(fk.Evaluations[i+half], fk.Evaluations[i]).(*mod.Int)
+		}
+		fk = fk.FixLastVariable(u[k])
+	}
+	if !fk.Evaluations[0].Equal(v) {
+		return nil, fmt.Errorf("f(u) is %s, not %s", fk.Evaluations[0].String(), v.String())
+	}
+
+	proof := &Proof{Q: make([]primitives.G1, n)}
+	bind(tr, c, u, v)
+	for k := range qs {
+		proof.Q[k] = Polynomial_commitment.Commit(ts, new(primitives.Polynomial).Init(qs[k]))
+		tr.AppendG1("q", proof.Q[k])
+	}
+	y := tr.ChallengeScalar("y", field)
+
+	// q̂(X) = Σₖ yᵏ·X^(N-2ᵏ)·U(qₖ)(X), of degree less than N iff every U(qₖ)
+	// is of degree less than 2ᵏ
+	size := len(f.Evaluations)
+	qHat := zeros(field, size)
+	yPow := field.NewElement(1)
+	for k := range qs {
+		for i, q := range qs[k] {
+			j := size - len(qs[k]) + i
+			qHat[j] = new(mod.Int).Add(qHat[j], new(mod.Int).Mul(yPow, q)).(*mod.Int)
+		}
+		yPow = new(mod.Int).Mul(yPow, y).(*mod.Int)
+	}
+	proof.QHat, proof.Degree, err = Polynomial_commitment.CommitWithDegreeBound(ts, new(primitives.Polynomial).Init(qHat), size-1)
+	if err != nil {
+		return nil, err
+	}
+	tr.AppendG1("q hat", proof.QHat)
+	x := tr.ChallengeScalar("x", field)
+	z := tr.ChallengeScalar("z", field)
+
+	// W(X) = ζₓ(X) + z·Zₓ(X), with
+	// ζₓ(X) = q̂(X) - Σₖ yᵏ·x^(N-2ᵏ)·U(qₖ)(X)
+	// Zₓ(X) = U(f)(X) - v·Φₙ(x) - Σₖ cₖ(x)·U(qₖ)(X)
+	w := make([]*mod.Int, size)
+	for i := range w {
+		w[i] = new(mod.Int).Add(qHat[i], new(mod.Int).Mul(z, f.Evaluations[i])).(*mod.Int)
+	}
+	w[0] = new(mod.Int).Sub(w[0], new(mod.Int).Mul(z, new(mod.Int).Mul(v, phi(field, n, x)))).(*mod.Int)
+	ks := scalars(field, n, size, u, x, y, z)
+	for k := range qs {
+		for i, q := range qs[k] {
+			w[i] = new(mod.Int).Add(w[i], new(mod.Int).Mul(ks[k], q)).(*mod.Int)
+		}
+	}
+	pi, err := Polynomial_commitment.EvaluationProof(ts, new(primitives.Polynomial).Init(w), x, field.NewElement(0))
+	if err != nil {
+		return nil, err
+	}
+	proof.Pi = pi
+	tr.AppendG1("pi", pi)
+	return proof, nil
+}
+
+// Verify verifies the proof of f(u) = v for the commitment c
+func Verify(ts *Polynomial_commitment.TrustedSetup, c primitives.G1, proof *Proof, u []*mod.Int, v *mod.Int, tr *primitives.Transcript) bool {
+	n := len(u)
+	// U(f) has 2ⁿ coefficients, committed with the setup
+	if n >= 31 || 1<<n > len(ts.Tau1) || c == nil || v == nil || !wellFormed(proof, n) {
+		return false
+	}
+	size := 1 << n
+	if proof.Degree.D != size-1 {
+		return false
+	}
+	for _, uk := range u {
+		if uk == nil {
+			return false
+		}
+	}
+	field := ts.Curve.ScalarField()
+	bind(tr, c, u, v)
+	for k := range proof.Q {
+		tr.AppendG1("q", proof.Q[k])
+	}
+	y := tr.ChallengeScalar("y", field)
+	tr.AppendG1("q hat", proof.QHat)
+	x := tr.ChallengeScalar("x", field)
+	z := tr.ChallengeScalar("z", field)
+	tr.AppendG1("pi", proof.Pi)
+
+	if !Polynomial_commitment.VerifyDegreeBound(ts, proof.QHat, proof.Degree) {
+		return false
+	}
+
+	// [W(t)]₁ = [q̂(t)]₁ + z·c - z·v·Φₙ(x)·G + Σₖ kₖ·[U(qₖ)(t)]₁
+	ps := []primitives.G1{proof.QHat, c, ts.Curve.G1Generator()}
+	vPhi := new(mod.Int).Mul(z, new(mod.Int).Mul(v, phi(field, n, x)))
+	ks := []*big.Int{big.NewInt(1), &z.V, &new(mod.Int).Neg(vPhi).(*mod.Int).V}
+	for k, s := range scalars(field, n, size, u, x, y, z) {
+		ps = append(ps, proof.Q[k])
+		ks = append(ks, &s.V)
+	}
+	w := primitives.MultiScalarMultG1(ts.Curve, ps, ks)
+	return Polynomial_commitment.Verify(ts, w, proof.Pi, x, field.NewElement(0))
+}
+
+// wellFormed checks that the proof has all its points, with one quotient
+// commitment per variable
+func wellFormed(proof *Proof, n int) bool {
+	if proof == nil || len(proof.Q) != n || proof.QHat == nil || proof.Pi == nil || proof.Degree == nil || proof.Degree.Shifted == nil {
+		return false
+	}
+	for _, q := range proof.Q {
+		if q == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func bind(tr *primitives.Transcript, c primitives.G1, u []*mod.Int, v *mod.Int) {
+	tr.AppendG1("C", c)
+	tr.AppendScalars("u", u)
+	tr.AppendScalar("v", v)
+}
+
+// scalars returns the factors kₖ of U(qₖ) in W:
+// -yᵏ·x^(N-2ᵏ) - z·(x^(2ᵏ)·Φₙ₋ₖ₋₁(x^(2ᵏ⁺¹)) - uₖ·Φₙ₋ₖ(x^(2ᵏ)))
+func scalars(field primitives.Field, n, size int, u []*mod.Int, x, y, z *mod.Int) []*mod.Int {
+	ks := make([]*mod.Int, n)
+	yPow := field.NewElement(1)
+	x2k := x // x^(2ᵏ)
+	for k := range ks {
+		shift := new(mod.Int).Exp(x, big.NewInt(int64(size-(1<<k))))
+		x2k1 := new(mod.Int).Mul(x2k, x2k).(*mod.Int)
+		ck := new(mod.Int).Sub(
+			new(mod.Int).Mul(x2k, phi(field, n-k-1, x2k1)),
+			new(mod.Int).Mul(u[k], phi(field, n-k, x2k)))
+		ks[k] = new(mod.Int).Neg(new(mod.Int).Add(new(mod.Int).Mul(yPow, shift), new(mod.Int).Mul(z, ck))).(*mod.Int)
+		yPow = new(mod.Int).Mul(yPow, y).(*mod.Int)
+		x2k = x2k1
+	}
+	return ks
+}
+
+// phi returns Φₖ(a) = Σ_{i<2ᵏ} aⁱ = Π_{j<k} (1 + a^(2ʲ))
+func phi(field primitives.Field, k int, a *mod.Int) *mod.Int {
+	r := field.NewElement(1)
+	one := field.NewElement(1)
+	for j := 0; j < k; j++ {
+		r = new(mod.Int).Mul(r, new(mod.Int).Add(one, a)).(*mod.Int)
+		a = new(mod.Int).Mul(a, a).(*mod.Int)
+	}
+	return r
+}
+
+func zeros(field primitives.Field, n int) []*mod.Int {
+	zs := make([]*mod.Int, n)
+	for i := range zs {
+		zs[i] = field.NewElement(0)
+	}
+	return zs
+}
@@ -0,0 +1,83 @@
+package zeromorph
+
+import (
+	"commitment/Polynomial_commitment"
+	"commitment/primitives"
+	"github.com/drand/kyber/group/mod"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSimpleFlow(t *testing.T) {
+	for _, curve := range []primitives.Curve{primitives.CurveBN254, primitives.CurveBLS12381} {
+		f := curve.ScalarField()
+		// a setup longer than the polynomial
+		ts, err := Polynomial_commitment.NewTrustedSetupOver(curve, 12)
+		assert.Nil(t, err)
+		evals, err := f.RandVector(8)
+		assert.Nil(t, err)
+		p, err := primitives.NewMultilinearPoly(evals)
+		assert.Nil(t, err)
+		c, err := Commit(ts, p)
+		assert.Nil(t, err)
+
+		u, err := f.RandVector(3)
+		assert.Nil(t, err)
+		v, _ := p.Evaluate(u)
+		proof, err := EvaluationProof(ts, p, u, v, primitives.NewTranscript("test"))
+		assert.Nil(t, err)
+		assert.True(t, Verify(ts, c, proof, u, v, primitives.NewTranscript("test")), curve.Name())
+
+		assert.False(t, Verify(ts, c, proof, u, u[0], primitives.NewTranscript("test")), curve.Name())
+		assert.False(t, Verify(ts, c, proof, []*mod.Int{u[0], u[2], u[1]}, v, primitives.NewTranscript("test")), curve.Name())
+		assert.False(t, Verify(ts, c, proof, u, v, primitives.NewTranscript("other")), curve.Name())
+
+		_, err = EvaluationProof(ts, p, u, u[0], primitives.NewTranscript("test"))
+		assert.NotNil(t, err)
+	}
+}
+
+func TestVerifyMalformed(t *testing.T) {
+	f := primitives.BN254
+	ts, err := Polynomial_commitment.NewTrustedSetupOver(primitives.CurveBN254, 8)
+	assert.Nil(t, err)
+	evals, err := f.RandVector(8)
+	assert.Nil(t, err)
+	p, err := primitives.NewMultilinearPoly(evals)
+	assert.Nil(t, err)
+	c, _ := Commit(ts, p)
+	u, err := f.RandVector(3)
+	assert.Nil(t, err)
+	v, _ := p.Evaluate(u)
+	proof, err := EvaluationProof(ts, p, u, v, primitives.NewTranscript("test"))
+	assert.Nil(t, err)
+
+	assert.False(t, Verify(ts, c, nil, u, v, primitives.NewTranscript("test")))
+	assert.False(t, Verify(ts, nil, proof, u, v, primitives.NewTranscript("test")))
+	// more variables than the setup supports, or than an int shifts
+	u4, err := f.RandVector(4)
+	assert.Nil(t, err)
+	assert.False(t, Verify(ts, c, proof, u4, v, primitives.NewTranscript("test")))
+	assert.False(t, Verify(ts, c, proof, make([]*mod.Int, 64), v, primitives.NewTranscript("test")))
+	for _, malform := range []func(*Proof){
+		func(p *Proof) { p.Q[1] = nil },
+		func(p *Proof) { p.Q = p.Q[:2] },
+		func(p *Proof) { p.QHat = nil },
+		func(p *Proof) { p.Pi = nil },
+		func(p *Proof) { p.Degree = nil },
+		func(p *Proof) { p.Degree = &Polynomial_commitment.DegreeBoundProof{D: 7} },
+	} {
+		bad := *proof
+		bad.Q = append([]primitives.G1{}, proof.Q...)
+		malform(&bad)
+		assert.False(t, Verify(ts, c, &bad, u, v, primitives.NewTranscript("test")))
+	}
+	assert.True(t, Verify(ts, c, proof, u, v, primitives.NewTranscript("test")))
+}
+
+func TestPhi(t *testing.T) {
+	a := primitives.BN254.NewElement(3)
+	// 1 + 3 + 9 + 27
+	assert.True(t, phi(primitives.BN254, 2, a).Equal(primitives.BN254.NewElement(40)))
+	assert.True(t, phi(primitives.BN254, 0, a).Equal(primitives.BN254.NewElement(1)))
+}